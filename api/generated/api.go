@@ -15,12 +15,27 @@ import (
 	"net/http"
 )
 
+// AlarmsAPIRouter defines the required methods for binding the api requests to a responses for the AlarmsAPI
+// The AlarmsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a AlarmsAPIServicer to perform the required actions, then write the service results to the http response.
+type AlarmsAPIRouter interface {
+	GetAlarmRules(http.ResponseWriter, *http.Request)
+	PostAlarmRule(http.ResponseWriter, *http.Request)
+	GetAlarmRuleById(http.ResponseWriter, *http.Request)
+	PutAlarmRuleById(http.ResponseWriter, *http.Request)
+	DeleteAlarmRuleById(http.ResponseWriter, *http.Request)
+}
+
 // ConfigurationAPIRouter defines the required methods for binding the api requests to a responses for the ConfigurationAPI
 // The ConfigurationAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a ConfigurationAPIServicer to perform the required actions, then write the service results to the http response.
 type ConfigurationAPIRouter interface {
-	GetConfiguration(http.ResponseWriter, *http.Request)
+	GetConfigurations(http.ResponseWriter, *http.Request)
+	PostConfiguration(http.ResponseWriter, *http.Request)
 	PutConfiguration(http.ResponseWriter, *http.Request)
+	GetConfigurationById(http.ResponseWriter, *http.Request)
+	PatchConfigurationById(http.ResponseWriter, *http.Request)
+	DeleteConfigurationById(http.ResponseWriter, *http.Request)
 }
 
 // CustomizationAPIRouter defines the required methods for binding the api requests to a responses for the CustomizationAPI
@@ -30,6 +45,24 @@ type CustomizationAPIRouter interface {
 	GetDashboardTemplateByName(http.ResponseWriter, *http.Request)
 }
 
+// EmissionsAPIRouter defines the required methods for binding the api requests to a responses for the EmissionsAPI
+// The EmissionsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a EmissionsAPIServicer to perform the required actions, then write the service results to the http response.
+type EmissionsAPIRouter interface {
+	GetBuildingMeters(http.ResponseWriter, *http.Request)
+	PostBuildingMeter(http.ResponseWriter, *http.Request)
+	GetBuildingMeterById(http.ResponseWriter, *http.Request)
+	PutBuildingMeterById(http.ResponseWriter, *http.Request)
+	DeleteBuildingMeterById(http.ResponseWriter, *http.Request)
+}
+
+// ZonesAPIRouter defines the required methods for binding the api requests to a responses for the ZonesAPI
+// The ZonesAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a ZonesAPIServicer to perform the required actions, then write the service results to the http response.
+type ZonesAPIRouter interface {
+	GetZones(http.ResponseWriter, *http.Request)
+}
+
 // VersionAPIRouter defines the required methods for binding the api requests to a responses for the VersionAPI
 // The VersionAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a VersionAPIServicer to perform the required actions, then write the service results to the http response.
@@ -38,13 +71,29 @@ type VersionAPIRouter interface {
 	GetOpenAPI(http.ResponseWriter, *http.Request)
 }
 
+// AlarmsAPIServicer defines the api actions for the AlarmsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type AlarmsAPIServicer interface {
+	GetAlarmRules(context.Context, int32) (ImplResponse, error)
+	PostAlarmRule(context.Context, AlarmRule) (ImplResponse, error)
+	GetAlarmRuleById(context.Context, int64) (ImplResponse, error)
+	PutAlarmRuleById(context.Context, int64, AlarmRule) (ImplResponse, error)
+	DeleteAlarmRuleById(context.Context, int64) (ImplResponse, error)
+}
+
 // ConfigurationAPIServicer defines the api actions for the ConfigurationAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type ConfigurationAPIServicer interface {
-	GetConfiguration(context.Context) (ImplResponse, error)
+	GetConfigurations(context.Context) (ImplResponse, error)
+	PostConfiguration(context.Context, Configuration) (ImplResponse, error)
 	PutConfiguration(context.Context, Configuration) (ImplResponse, error)
+	GetConfigurationById(context.Context, int64) (ImplResponse, error)
+	PatchConfigurationById(context.Context, int64, Configuration) (ImplResponse, error)
+	DeleteConfigurationById(context.Context, int64) (ImplResponse, error)
 }
 
 // CustomizationAPIServicer defines the api actions for the CustomizationAPI service
@@ -55,6 +104,26 @@ type CustomizationAPIServicer interface {
 	GetDashboardTemplateByName(context.Context, string, string) (ImplResponse, error)
 }
 
+// EmissionsAPIServicer defines the api actions for the EmissionsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type EmissionsAPIServicer interface {
+	GetBuildingMeters(context.Context) (ImplResponse, error)
+	PostBuildingMeter(context.Context, BuildingMeter) (ImplResponse, error)
+	GetBuildingMeterById(context.Context, int64) (ImplResponse, error)
+	PutBuildingMeterById(context.Context, int64, BuildingMeter) (ImplResponse, error)
+	DeleteBuildingMeterById(context.Context, int64) (ImplResponse, error)
+}
+
+// ZonesAPIServicer defines the api actions for the ZonesAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type ZonesAPIServicer interface {
+	GetZones(context.Context, string, int64) (ImplResponse, error)
+}
+
 // VersionAPIServicer defines the api actions for the VersionAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -16,6 +16,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // ConfigurationAPIController binds http requests to an api service and writes the service results to the http response
@@ -51,22 +53,42 @@ func NewConfigurationAPIController(s ConfigurationAPIServicer, opts ...Configura
 // Routes returns all the api routes for the ConfigurationAPIController
 func (c *ConfigurationAPIController) Routes() Routes {
 	return Routes{
-		"GetConfiguration": Route{
+		"GetConfigurations": Route{
 			strings.ToUpper("Get"),
 			"/v1/configs",
-			c.GetConfiguration,
+			c.GetConfigurations,
+		},
+		"PostConfiguration": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs",
+			c.PostConfiguration,
 		},
 		"PutConfiguration": Route{
 			strings.ToUpper("Put"),
 			"/v1/configs",
 			c.PutConfiguration,
 		},
+		"GetConfigurationById": Route{
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}",
+			c.GetConfigurationById,
+		},
+		"PatchConfigurationById": Route{
+			strings.ToUpper("Patch"),
+			"/v1/configs/{config-id}",
+			c.PatchConfigurationById,
+		},
+		"DeleteConfigurationById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/configs/{config-id}",
+			c.DeleteConfigurationById,
+		},
 	}
 }
 
-// GetConfiguration - Get configuration
-func (c *ConfigurationAPIController) GetConfiguration(w http.ResponseWriter, r *http.Request) {
-	result, err := c.service.GetConfiguration(r.Context())
+// GetConfigurations - Get configurations
+func (c *ConfigurationAPIController) GetConfigurations(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetConfigurations(r.Context())
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -76,7 +98,34 @@ func (c *ConfigurationAPIController) GetConfiguration(w http.ResponseWriter, r *
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
 
-// PutConfiguration - Updates the configuration
+// PostConfiguration - Creates a configuration
+func (c *ConfigurationAPIController) PostConfiguration(w http.ResponseWriter, r *http.Request) {
+	var configurationParam Configuration
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&configurationParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertConfigurationRequired(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertConfigurationConstraints(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostConfiguration(r.Context(), configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PutConfiguration - Updates a configuration
 func (c *ConfigurationAPIController) PutConfiguration(w http.ResponseWriter, r *http.Request) {
 	var configurationParam Configuration
 	d := json.NewDecoder(r.Body)
@@ -102,3 +151,81 @@ func (c *ConfigurationAPIController) PutConfiguration(w http.ResponseWriter, r *
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// GetConfigurationById - Get configuration
+func (c *ConfigurationAPIController) GetConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "config-id", Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetConfigurationById(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PatchConfigurationById - Partially updates a configuration
+func (c *ConfigurationAPIController) PatchConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "config-id", Err: err}, nil)
+		return
+	}
+	var configurationParam Configuration
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&configurationParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertConfigurationRequired(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertConfigurationConstraints(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PatchConfigurationById(r.Context(), configIdParam, configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// DeleteConfigurationById - Deletes a configuration
+func (c *ConfigurationAPIController) DeleteConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "config-id", Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteConfigurationById(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
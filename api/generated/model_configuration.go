@@ -10,20 +10,24 @@
 
 package apiserver
 
+import (
+	"errors"
+)
+
 // Configuration - Each configuration defines access to provider's API.
 type Configuration struct {
 
 	// Internal identifier for the configured API (created automatically).
 	Id *int64 `json:"id,omitempty"`
 
-	// API key obtained at https://portal.electricitymaps.com.
+	// API key obtained at https://portal.electricitymaps.com. Masked in responses, so it is replaced only if a new key is sent.
 	ApiKey string `json:"apiKey,omitempty"`
 
 	// Flag to enable or disable fetching from this API
 	Enable *bool `json:"enable,omitempty"`
 
 	// Interval in seconds for collecting data from API
-	RefreshInterval int32 `json:"refreshInterval,omitempty"`
+	RefreshInterval *int32 `json:"refreshInterval,omitempty"`
 
 	// Timeout in seconds
 	RequestTimeout *int32 `json:"requestTimeout,omitempty"`
@@ -36,6 +40,54 @@ type Configuration struct {
 
 	// ID of the last Eliona user who created or updated the configuration
 	UserId *string `json:"userId,omitempty"`
+
+	// Number of hours of past data loaded when an asset is mapped to a zone. Zero disables the backfill.
+	BackfillHours *int32 `json:"backfillHours,omitempty"`
+
+	// Base URL of the Electricity Maps API. Can point to a regional endpoint, a proxy or a mock server.
+	ApiBaseUrl *string `json:"apiBaseUrl,omitempty"`
+
+	// Version of the Electricity Maps API
+	ApiVersion *string `json:"apiVersion,omitempty"`
+
+	// Maximum number of requests per second sent to the Electricity Maps API. Zero means unlimited.
+	RequestsPerSecond *float64 `json:"requestsPerSecond,omitempty"`
+
+	// Number of requests per month included in the API plan. Collection stops for the rest of the month once it is used up. Zero means unlimited.
+	MonthlyRequestLimit *int64 `json:"monthlyRequestLimit,omitempty"`
+
+	// Percentage of zone assets failing in a collection from which the app status is Error. Below, the status is Degraded.
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// Number of zones fetched concurrently
+	Workers *int32 `json:"workers,omitempty"`
+
+	// Number of days zone readings are kept in the app's history. Zero keeps them forever.
+	HistoryRetentionDays *int32 `json:"historyRetentionDays,omitempty"`
+
+	// Create an Electricity Zone asset for every zone the building assets of the configured projects are located in.
+	AutoProvision *bool `json:"autoProvision,omitempty"`
+
+	// Asset type of the buildings considered by the automatic provisioning.
+	BuildingAssetType *string `json:"buildingAssetType,omitempty"`
+
+	// Emission factors the carbon intensity is based on. Lifecycle factors include the emissions of building and fuelling power plants, direct factors only those of the combustion.
+	EmissionFactorType *string `json:"emissionFactorType,omitempty"`
+
+	// Collect the marginal carbon intensity in addition to the average one. Requires an API plan with marginal signal access.
+	MarginalSignal *bool `json:"marginalSignal,omitempty"`
+
+	// Duration in hours of the flexible load the greenest window is searched for. Zero disables the search.
+	FlexibleLoadHours *int32 `json:"flexibleLoadHours,omitempty"`
+
+	// Time of day (HH:MM) the flexible load may start at the earliest.
+	FlexibleLoadEarliestStart *string `json:"flexibleLoadEarliestStart,omitempty"`
+
+	// Time of day (HH:MM) the flexible load has to be finished by. If it is not after the earliest start, it is on the next day.
+	FlexibleLoadDeadline *string `json:"flexibleLoadDeadline,omitempty"`
+
+	// IANA time zone the times of day of the flexible load are in, e.g. Europe/Zurich.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
@@ -45,5 +97,41 @@ func AssertConfigurationRequired(obj Configuration) error {
 
 // AssertConfigurationConstraints checks if the values respects the defined constraints
 func AssertConfigurationConstraints(obj Configuration) error {
+	if obj.RefreshInterval != nil && *obj.RefreshInterval < 1 {
+		return &ParsingError{Param: "RefreshInterval", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.RequestTimeout != nil && *obj.RequestTimeout < 1 {
+		return &ParsingError{Param: "RequestTimeout", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.BackfillHours != nil && *obj.BackfillHours < 0 {
+		return &ParsingError{Param: "BackfillHours", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.BackfillHours != nil && *obj.BackfillHours > 720 {
+		return &ParsingError{Param: "BackfillHours", Err: errors.New(errMsgMaxValueConstraint)}
+	}
+	if obj.RequestsPerSecond != nil && *obj.RequestsPerSecond < 0 {
+		return &ParsingError{Param: "RequestsPerSecond", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.MonthlyRequestLimit != nil && *obj.MonthlyRequestLimit < 0 {
+		return &ParsingError{Param: "MonthlyRequestLimit", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.FailureThreshold != nil && *obj.FailureThreshold < 0 {
+		return &ParsingError{Param: "FailureThreshold", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.FailureThreshold != nil && *obj.FailureThreshold > 100 {
+		return &ParsingError{Param: "FailureThreshold", Err: errors.New(errMsgMaxValueConstraint)}
+	}
+	if obj.Workers != nil && *obj.Workers < 1 {
+		return &ParsingError{Param: "Workers", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.HistoryRetentionDays != nil && *obj.HistoryRetentionDays < 0 {
+		return &ParsingError{Param: "HistoryRetentionDays", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.FlexibleLoadHours != nil && *obj.FlexibleLoadHours < 0 {
+		return &ParsingError{Param: "FlexibleLoadHours", Err: errors.New(errMsgMinValueConstraint)}
+	}
+	if obj.FlexibleLoadHours != nil && *obj.FlexibleLoadHours > 24 {
+		return &ParsingError{Param: "FlexibleLoadHours", Err: errors.New(errMsgMaxValueConstraint)}
+	}
 	return nil
 }
@@ -0,0 +1,206 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	apiserver "electricity-maps/api/generated"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"electricity-maps/eliona"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// AlarmsAPIService is a service that implements the logic for the AlarmsAPIServicer
+// This service should implement the business logic for every endpoint for the AlarmsAPI API.
+// Include any external packages or services that will be required by this service.
+type AlarmsAPIService struct {
+}
+
+// NewAlarmsAPIService creates a default api service
+func NewAlarmsAPIService() apiserver.AlarmsAPIServicer {
+	return &AlarmsAPIService{}
+}
+
+// GetAlarmRules - Get alarm rules
+func (s *AlarmsAPIService) GetAlarmRules(ctx context.Context, assetId int32) (apiserver.ImplResponse, error) {
+	var rules []appmodel.AlarmRule
+	var err error
+	if assetId != 0 {
+		rules, err = dbhelper.GetAssetAlarmRules(ctx, assetId)
+	} else {
+		rules, err = dbhelper.GetAlarmRules(ctx)
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	apiRules := []apiserver.AlarmRule{}
+	for _, rule := range rules {
+		apiRule, err := toAPIAlarmRule(ctx, rule)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		apiRules = append(apiRules, apiRule)
+	}
+	return apiserver.Response(http.StatusOK, apiRules), nil
+}
+
+// PostAlarmRule - Creates an alarm rule
+func (s *AlarmsAPIService) PostAlarmRule(ctx context.Context, alarmRule apiserver.AlarmRule) (apiserver.ImplResponse, error) {
+	return s.upsertAlarmRule(ctx, 0, alarmRule, http.StatusCreated)
+}
+
+// GetAlarmRuleById - Get alarm rule
+func (s *AlarmsAPIService) GetAlarmRuleById(ctx context.Context, alarmRuleId int64) (apiserver.ImplResponse, error) {
+	rule, err := dbhelper.GetAlarmRule(ctx, alarmRuleId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	apiRule, err := toAPIAlarmRule(ctx, rule)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, apiRule), nil
+}
+
+// PutAlarmRuleById - Updates an alarm rule
+func (s *AlarmsAPIService) PutAlarmRuleById(ctx context.Context, alarmRuleId int64, alarmRule apiserver.AlarmRule) (apiserver.ImplResponse, error) {
+	return s.upsertAlarmRule(ctx, alarmRuleId, alarmRule, http.StatusOK)
+}
+
+// DeleteAlarmRuleById - Deletes an alarm rule
+func (s *AlarmsAPIService) DeleteAlarmRuleById(ctx context.Context, alarmRuleId int64) (apiserver.ImplResponse, error) {
+	err := dbhelper.DeleteAlarmRule(ctx, alarmRuleId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+func (s *AlarmsAPIService) upsertAlarmRule(ctx context.Context, alarmRuleId int64, alarmRule apiserver.AlarmRule, code int) (apiserver.ImplResponse, error) {
+	rule := toAppAlarmRule(alarmRule)
+	rule.ID = alarmRuleId
+	if rule.Comparison != appmodel.AlarmAbove && rule.Comparison != appmodel.AlarmBelow {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("comparison must be %s or %s, not %q", appmodel.AlarmAbove, appmodel.AlarmBelow, rule.Comparison)
+	}
+	// A negative hysteresis would clear the alarm while the value is still beyond the threshold.
+	if rule.Hysteresis < 0 {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("hysteresis must not be negative, not %v", rule.Hysteresis)
+	}
+	if rule.MinDurationMinutes < 0 {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("minDurationMinutes must not be negative, not %d", rule.MinDurationMinutes)
+	}
+	if _, err := dbhelper.GetAssetById(rule.AssetID); errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("asset %v is not a zone asset of the app", rule.AssetID)
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	// Alarms are evaluated on the numeric values written with every reading.
+	numeric, err := eliona.IsNumericInput(rule.AssetID, rule.Attribute)
+	if err != nil && !errors.Is(err, eliona.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if !numeric {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("attribute %q is not a numeric input attribute of the zone asset", rule.Attribute)
+	}
+
+	var previous appmodel.AlarmRule
+	if rule.ID != 0 {
+		previous, err = dbhelper.GetAlarmRule(ctx, rule.ID)
+		if errors.Is(err, dbhelper.ErrNotFound) {
+			return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+		} else if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+	}
+
+	upserted, err := dbhelper.UpsertAlarmRule(ctx, rule)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	// The state was reached under the old condition, so it is evaluated anew.
+	if rule.ID != 0 && conditionChanged(previous, upserted) {
+		if err := dbhelper.DeleteAlarmState(ctx, rule.ID); err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+	}
+	apiRule, err := toAPIAlarmRule(ctx, upserted)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(code, apiRule), nil
+}
+
+// conditionChanged tells whether the rule fires on other values than before
+func conditionChanged(previous, rule appmodel.AlarmRule) bool {
+	return previous.AssetID != rule.AssetID ||
+		previous.Attribute != rule.Attribute ||
+		previous.Comparison != rule.Comparison ||
+		previous.Threshold != rule.Threshold ||
+		previous.Hysteresis != rule.Hysteresis ||
+		previous.MinDurationMinutes != rule.MinDurationMinutes
+}
+
+func toAPIAlarmRule(ctx context.Context, rule appmodel.AlarmRule) (apiserver.AlarmRule, error) {
+	state, err := dbhelper.GetAlarmState(ctx, rule.ID)
+	if err != nil {
+		return apiserver.AlarmRule{}, err
+	}
+	return apiserver.AlarmRule{
+		Id:                 &rule.ID,
+		AssetId:            rule.AssetID,
+		Attribute:          rule.Attribute,
+		Comparison:         rule.Comparison,
+		Threshold:          rule.Threshold,
+		Hysteresis:         &rule.Hysteresis,
+		MinDurationMinutes: &rule.MinDurationMinutes,
+		Enable:             &rule.Enable,
+		Message:            &rule.Message,
+		Active:             &state.Active,
+	}, nil
+}
+
+func toAppAlarmRule(apiRule apiserver.AlarmRule) appmodel.AlarmRule {
+	rule := appmodel.AlarmRule{
+		AssetID:    apiRule.AssetId,
+		Attribute:  apiRule.Attribute,
+		Comparison: apiRule.Comparison,
+		Threshold:  apiRule.Threshold,
+		Enable:     true,
+	}
+	if apiRule.Hysteresis != nil {
+		rule.Hysteresis = *apiRule.Hysteresis
+	}
+	if apiRule.MinDurationMinutes != nil {
+		rule.MinDurationMinutes = *apiRule.MinDurationMinutes
+	}
+	if apiRule.Enable != nil {
+		rule.Enable = *apiRule.Enable
+	}
+	if apiRule.Message != nil {
+		rule.Message = *apiRule.Message
+	}
+	return rule
+}
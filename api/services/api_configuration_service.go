@@ -21,10 +21,11 @@ import (
 	appmodel "electricity-maps/app/model"
 	"electricity-maps/broker"
 	dbhelper "electricity-maps/db/helper"
+	"errors"
 	"fmt"
 	"net/http"
-
-	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"strings"
+	"time"
 )
 
 // ConfigurationAPIService is a service that implements the logic for the ConfigurationAPIServicer
@@ -38,37 +39,196 @@ func NewConfigurationAPIService() apiserver.ConfigurationAPIServicer {
 	return &ConfigurationAPIService{}
 }
 
-func (s *ConfigurationAPIService) GetConfiguration(ctx context.Context) (apiserver.ImplResponse, error) {
-	appConfig, err := dbhelper.GetConfig(ctx)
+func (s *ConfigurationAPIService) GetConfigurations(ctx context.Context) (apiserver.ImplResponse, error) {
+	appConfigs, err := dbhelper.GetConfigs(ctx)
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusOK, toAPIConfig(appConfig)), nil
+	apiConfigs := []apiserver.Configuration{}
+	for _, appConfig := range appConfigs {
+		apiConfigs = append(apiConfigs, toAPIConfig(appConfig))
+	}
+	return apiserver.Response(http.StatusOK, apiConfigs), nil
 }
 
+func (s *ConfigurationAPIService) PostConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	config.Id = nil
+	return s.saveConfiguration(ctx, toAppConfig(config), true, http.StatusCreated)
+}
+
+// PutConfiguration replaces the configuration with the ID. Without ID, it replaces the sole
+// configuration like before there could be several, or creates it if there is none yet.
 func (s *ConfigurationAPIService) PutConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
-	config.Id = api.PtrInt64(1)
-	appConfig := toAppConfig(config)
-	if err := broker.TestAuthentication(appConfig); err != nil {
-		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("testing authentication: %v", err)
+	if config.Id == nil {
+		existing, err := dbhelper.GetConfigs(ctx)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		switch len(existing) {
+		case 0:
+			return s.saveConfiguration(ctx, toAppConfig(config), true, http.StatusCreated)
+		case 1:
+			return s.updateConfiguration(ctx, existing[0], toAppConfig(config))
+		default:
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, errors.New("id: required as there are several configurations, use POST to create one")
+		}
+	}
+	existing, err := dbhelper.GetConfig(ctx, *config.Id)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return s.updateConfiguration(ctx, existing, toAppConfig(config))
+}
+
+func (s *ConfigurationAPIService) GetConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	appConfig, err := dbhelper.GetConfig(ctx, configId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, toAPIConfig(appConfig)), nil
+}
+
+// PatchConfigurationById updates only the fields sent
+func (s *ConfigurationAPIService) PatchConfigurationById(ctx context.Context, configId int64, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	existing, err := dbhelper.GetConfig(ctx, configId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return s.updateConfiguration(ctx, existing, patchAppConfig(existing, config))
+}
+
+func (s *ConfigurationAPIService) DeleteConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	err := dbhelper.DeleteConfig(ctx, configId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// updateConfiguration saves the changes to an existing configuration. The API key is kept unless a
+// new one is sent instead of the masked one.
+func (s *ConfigurationAPIService) updateConfiguration(ctx context.Context, existing, appConfig appmodel.Configuration) (apiserver.ImplResponse, error) {
+	appConfig.Id = existing.Id
+	appConfig.Active = existing.Active
+	if appConfig.ApiKey == "" || appConfig.ApiKey == maskApiKey(existing.ApiKey) {
+		appConfig.ApiKey = existing.ApiKey
+	}
+	// Authentication is tested only if it may have changed, so that e.g. disabling a configuration
+	// does not depend on Electricity Maps being reachable.
+	testAuthentication := appConfig.ApiKey != existing.ApiKey ||
+		appConfig.ApiBaseUrl != existing.ApiBaseUrl ||
+		appConfig.ApiVersion != existing.ApiVersion
+	return s.saveConfiguration(ctx, appConfig, testAuthentication, http.StatusOK)
+}
+
+func (s *ConfigurationAPIService) saveConfiguration(ctx context.Context, appConfig appmodel.Configuration, testAuthentication bool, code int) (apiserver.ImplResponse, error) {
+	if err := validateConfig(appConfig); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	if testAuthentication {
+		if err := broker.NewBudgetedClient(appConfig).TestAuthentication(ctx); err != nil {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("apiKey: testing authentication: %v", err)
+		}
 	}
 	upsertedConfig, err := dbhelper.UpsertConfig(ctx, appConfig)
-	if err != nil {
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusCreated, toAPIConfig(upsertedConfig)), nil
+	return apiserver.Response(code, toAPIConfig(upsertedConfig)), nil
+}
+
+// validateConfig checks the configuration as a whole. Value ranges of single fields are already
+// checked against the schema. Errors name the offending field.
+func validateConfig(appConfig appmodel.Configuration) error {
+	if strings.TrimSpace(appConfig.ApiKey) == "" {
+		return errors.New("apiKey: an API key is required")
+	}
+	if len(appConfig.ProjectIDs) == 0 {
+		return errors.New("projectIDs: at least one project is required")
+	}
+	for _, projectID := range appConfig.ProjectIDs {
+		if strings.TrimSpace(projectID) == "" {
+			return errors.New("projectIDs: project IDs must not be empty")
+		}
+	}
+	if appConfig.RefreshInterval < 1 {
+		return fmt.Errorf("refreshInterval: must be at least 1 second, not %d", appConfig.RefreshInterval)
+	}
+	if appConfig.RequestTimeout < 1 {
+		return fmt.Errorf("requestTimeout: must be at least 1 second, not %d", appConfig.RequestTimeout)
+	}
+	if appConfig.EmissionFactorType != "lifecycle" && appConfig.EmissionFactorType != "direct" {
+		return fmt.Errorf("emissionFactorType: must be lifecycle or direct, not %q", appConfig.EmissionFactorType)
+	}
+	if appConfig.FlexibleLoadHours < 0 || appConfig.FlexibleLoadHours > 24 {
+		return fmt.Errorf("flexibleLoadHours: must be between 0 and 24, not %d", appConfig.FlexibleLoadHours)
+	}
+	earliestStart, err := appmodel.ParseTimeOfDay(appConfig.FlexibleLoadEarliestStart)
+	if err != nil {
+		return fmt.Errorf("flexibleLoadEarliestStart: %q is not formatted as HH:MM", appConfig.FlexibleLoadEarliestStart)
+	}
+	deadline, err := appmodel.ParseTimeOfDay(appConfig.FlexibleLoadDeadline)
+	if err != nil {
+		return fmt.Errorf("flexibleLoadDeadline: %q is not formatted as HH:MM", appConfig.FlexibleLoadDeadline)
+	}
+	if _, err := time.LoadLocation(appConfig.TimeZone); err != nil {
+		return fmt.Errorf("timeZone: %q is not an IANA time zone", appConfig.TimeZone)
+	}
+	// A load longer than the period between earliest start and deadline would never find a window.
+	if length := appmodel.SchedulingPeriodLength(earliestStart, deadline); time.Duration(appConfig.FlexibleLoadHours)*time.Hour > length {
+		return fmt.Errorf("flexibleLoadHours: %d hours do not fit between %s and %s", appConfig.FlexibleLoadHours, appConfig.FlexibleLoadEarliestStart, appConfig.FlexibleLoadDeadline)
+	}
+	return nil
+}
+
+// apiKeyVisibleChars is the number of trailing characters of the API key shown in responses
+const apiKeyVisibleChars = 4
+
+// maskApiKey hides the API key but its last characters, so that it can still be told apart. Short
+// keys are hidden completely.
+func maskApiKey(apiKey string) string {
+	if len(apiKey) <= 2*apiKeyVisibleChars {
+		return strings.Repeat("*", 2*apiKeyVisibleChars)
+	}
+	return strings.Repeat("*", apiKeyVisibleChars) + apiKey[len(apiKey)-apiKeyVisibleChars:]
 }
 
 func toAPIConfig(appConfig appmodel.Configuration) apiserver.Configuration {
 	return apiserver.Configuration{
-		Id:              &appConfig.Id,
-		ApiKey:          appConfig.ApiKey,
-		Enable:          &appConfig.Enable,
-		RefreshInterval: appConfig.RefreshInterval,
-		RequestTimeout:  &appConfig.RequestTimeout,
-		Active:          &appConfig.Active,
-		ProjectIDs:      &appConfig.ProjectIDs,
-		UserId:          &appConfig.UserId,
+		Id:                        &appConfig.Id,
+		ApiKey:                    maskApiKey(appConfig.ApiKey),
+		Enable:                    &appConfig.Enable,
+		RefreshInterval:           &appConfig.RefreshInterval,
+		RequestTimeout:            &appConfig.RequestTimeout,
+		Active:                    &appConfig.Active,
+		ProjectIDs:                &appConfig.ProjectIDs,
+		UserId:                    &appConfig.UserId,
+		BackfillHours:             &appConfig.BackfillHours,
+		ApiBaseUrl:                &appConfig.ApiBaseUrl,
+		ApiVersion:                &appConfig.ApiVersion,
+		RequestsPerSecond:         &appConfig.RequestsPerSecond,
+		MonthlyRequestLimit:       &appConfig.MonthlyRequestLimit,
+		FailureThreshold:          &appConfig.FailureThreshold,
+		Workers:                   &appConfig.Workers,
+		HistoryRetentionDays:      &appConfig.HistoryRetentionDays,
+		AutoProvision:             &appConfig.AutoProvision,
+		BuildingAssetType:         &appConfig.BuildingAssetType,
+		EmissionFactorType:        &appConfig.EmissionFactorType,
+		MarginalSignal:            &appConfig.MarginalSignal,
+		FlexibleLoadHours:         &appConfig.FlexibleLoadHours,
+		FlexibleLoadEarliestStart: &appConfig.FlexibleLoadEarliestStart,
+		FlexibleLoadDeadline:      &appConfig.FlexibleLoadDeadline,
+		TimeZone:                  &appConfig.TimeZone,
 	}
 }
 
@@ -78,7 +238,11 @@ func toAppConfig(apiConfig apiserver.Configuration) (appConfig appmodel.Configur
 	if apiConfig.Id != nil {
 		appConfig.Id = *apiConfig.Id
 	}
-	appConfig.RefreshInterval = apiConfig.RefreshInterval
+	appConfig.RefreshInterval = 60
+	if apiConfig.RefreshInterval != nil {
+		appConfig.RefreshInterval = *apiConfig.RefreshInterval
+	}
+	appConfig.RequestTimeout = 120
 	if apiConfig.RequestTimeout != nil {
 		appConfig.RequestTimeout = *apiConfig.RequestTimeout
 	}
@@ -92,5 +256,134 @@ func toAppConfig(apiConfig apiserver.Configuration) (appConfig appmodel.Configur
 	if apiConfig.ProjectIDs != nil {
 		appConfig.ProjectIDs = *apiConfig.ProjectIDs
 	}
+	appConfig.BackfillHours = 24
+	if apiConfig.BackfillHours != nil {
+		appConfig.BackfillHours = *apiConfig.BackfillHours
+	}
+	appConfig.ApiBaseUrl = "https://api.electricitymap.org"
+	if apiConfig.ApiBaseUrl != nil && *apiConfig.ApiBaseUrl != "" {
+		appConfig.ApiBaseUrl = *apiConfig.ApiBaseUrl
+	}
+	appConfig.ApiVersion = "v3"
+	if apiConfig.ApiVersion != nil && *apiConfig.ApiVersion != "" {
+		appConfig.ApiVersion = *apiConfig.ApiVersion
+	}
+	appConfig.RequestsPerSecond = 5
+	if apiConfig.RequestsPerSecond != nil {
+		appConfig.RequestsPerSecond = *apiConfig.RequestsPerSecond
+	}
+	if apiConfig.MonthlyRequestLimit != nil {
+		appConfig.MonthlyRequestLimit = *apiConfig.MonthlyRequestLimit
+	}
+	appConfig.FailureThreshold = 50
+	if apiConfig.FailureThreshold != nil {
+		appConfig.FailureThreshold = *apiConfig.FailureThreshold
+	}
+	appConfig.Workers = 4
+	if apiConfig.Workers != nil {
+		appConfig.Workers = *apiConfig.Workers
+	}
+	appConfig.HistoryRetentionDays = 30
+	if apiConfig.HistoryRetentionDays != nil {
+		appConfig.HistoryRetentionDays = *apiConfig.HistoryRetentionDays
+	}
+	if apiConfig.AutoProvision != nil {
+		appConfig.AutoProvision = *apiConfig.AutoProvision
+	}
+	appConfig.BuildingAssetType = "building"
+	if apiConfig.BuildingAssetType != nil && *apiConfig.BuildingAssetType != "" {
+		appConfig.BuildingAssetType = *apiConfig.BuildingAssetType
+	}
+	appConfig.EmissionFactorType = "lifecycle"
+	if apiConfig.EmissionFactorType != nil && *apiConfig.EmissionFactorType != "" {
+		appConfig.EmissionFactorType = *apiConfig.EmissionFactorType
+	}
+	if apiConfig.MarginalSignal != nil {
+		appConfig.MarginalSignal = *apiConfig.MarginalSignal
+	}
+	if apiConfig.FlexibleLoadHours != nil {
+		appConfig.FlexibleLoadHours = *apiConfig.FlexibleLoadHours
+	}
+	appConfig.FlexibleLoadEarliestStart = "00:00"
+	if apiConfig.FlexibleLoadEarliestStart != nil && *apiConfig.FlexibleLoadEarliestStart != "" {
+		appConfig.FlexibleLoadEarliestStart = *apiConfig.FlexibleLoadEarliestStart
+	}
+	appConfig.FlexibleLoadDeadline = "00:00"
+	if apiConfig.FlexibleLoadDeadline != nil && *apiConfig.FlexibleLoadDeadline != "" {
+		appConfig.FlexibleLoadDeadline = *apiConfig.FlexibleLoadDeadline
+	}
+	appConfig.TimeZone = "Europe/Zurich"
+	if apiConfig.TimeZone != nil && *apiConfig.TimeZone != "" {
+		appConfig.TimeZone = *apiConfig.TimeZone
+	}
+	return appConfig
+}
+
+// patchAppConfig applies the fields sent to the existing configuration
+func patchAppConfig(existing appmodel.Configuration, apiConfig apiserver.Configuration) appmodel.Configuration {
+	appConfig := existing
+	if apiConfig.ApiKey != "" {
+		appConfig.ApiKey = apiConfig.ApiKey
+	}
+	if apiConfig.Enable != nil {
+		appConfig.Enable = *apiConfig.Enable
+	}
+	if apiConfig.RefreshInterval != nil {
+		appConfig.RefreshInterval = *apiConfig.RefreshInterval
+	}
+	if apiConfig.RequestTimeout != nil {
+		appConfig.RequestTimeout = *apiConfig.RequestTimeout
+	}
+	if apiConfig.ProjectIDs != nil {
+		appConfig.ProjectIDs = *apiConfig.ProjectIDs
+	}
+	if apiConfig.BackfillHours != nil {
+		appConfig.BackfillHours = *apiConfig.BackfillHours
+	}
+	if apiConfig.ApiBaseUrl != nil && *apiConfig.ApiBaseUrl != "" {
+		appConfig.ApiBaseUrl = *apiConfig.ApiBaseUrl
+	}
+	if apiConfig.ApiVersion != nil && *apiConfig.ApiVersion != "" {
+		appConfig.ApiVersion = *apiConfig.ApiVersion
+	}
+	if apiConfig.RequestsPerSecond != nil {
+		appConfig.RequestsPerSecond = *apiConfig.RequestsPerSecond
+	}
+	if apiConfig.MonthlyRequestLimit != nil {
+		appConfig.MonthlyRequestLimit = *apiConfig.MonthlyRequestLimit
+	}
+	if apiConfig.FailureThreshold != nil {
+		appConfig.FailureThreshold = *apiConfig.FailureThreshold
+	}
+	if apiConfig.Workers != nil {
+		appConfig.Workers = *apiConfig.Workers
+	}
+	if apiConfig.HistoryRetentionDays != nil {
+		appConfig.HistoryRetentionDays = *apiConfig.HistoryRetentionDays
+	}
+	if apiConfig.AutoProvision != nil {
+		appConfig.AutoProvision = *apiConfig.AutoProvision
+	}
+	if apiConfig.BuildingAssetType != nil && *apiConfig.BuildingAssetType != "" {
+		appConfig.BuildingAssetType = *apiConfig.BuildingAssetType
+	}
+	if apiConfig.EmissionFactorType != nil && *apiConfig.EmissionFactorType != "" {
+		appConfig.EmissionFactorType = *apiConfig.EmissionFactorType
+	}
+	if apiConfig.MarginalSignal != nil {
+		appConfig.MarginalSignal = *apiConfig.MarginalSignal
+	}
+	if apiConfig.FlexibleLoadHours != nil {
+		appConfig.FlexibleLoadHours = *apiConfig.FlexibleLoadHours
+	}
+	if apiConfig.FlexibleLoadEarliestStart != nil && *apiConfig.FlexibleLoadEarliestStart != "" {
+		appConfig.FlexibleLoadEarliestStart = *apiConfig.FlexibleLoadEarliestStart
+	}
+	if apiConfig.FlexibleLoadDeadline != nil && *apiConfig.FlexibleLoadDeadline != "" {
+		appConfig.FlexibleLoadDeadline = *apiConfig.FlexibleLoadDeadline
+	}
+	if apiConfig.TimeZone != nil && *apiConfig.TimeZone != "" {
+		appConfig.TimeZone = *apiConfig.TimeZone
+	}
 	return appConfig
 }
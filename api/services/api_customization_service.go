@@ -18,6 +18,7 @@ package apiservices
 import (
 	"context"
 	apiserver "electricity-maps/api/generated"
+	"electricity-maps/eliona"
 	"net/http"
 )
 
@@ -35,7 +36,11 @@ func NewCustomizationAPIService() apiserver.CustomizationAPIServicer {
 // GetDashboardTemplateByName - Get a full dashboard template
 func (s *CustomizationAPIService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string) (apiserver.ImplResponse, error) {
 	if dashboardTemplateName == "Electricity Maps" {
-		return apiserver.ImplResponse{Code: http.StatusNotImplemented}, nil
+		dashboard, err := eliona.ElectricityMapsDashboard(projectId)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, dashboard), nil
 	} else {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
@@ -0,0 +1,173 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	apiserver "electricity-maps/api/generated"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"electricity-maps/eliona"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// EmissionsAPIService is a service that implements the logic for the EmissionsAPIServicer
+// This service should implement the business logic for every endpoint for the EmissionsAPI API.
+// Include any external packages or services that will be required by this service.
+type EmissionsAPIService struct {
+}
+
+// NewEmissionsAPIService creates a default api service
+func NewEmissionsAPIService() apiserver.EmissionsAPIServicer {
+	return &EmissionsAPIService{}
+}
+
+// GetBuildingMeters - Get building meters
+func (s *EmissionsAPIService) GetBuildingMeters(ctx context.Context) (apiserver.ImplResponse, error) {
+	meters, err := dbhelper.GetBuildingMeters(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	apiMeters := []apiserver.BuildingMeter{}
+	for _, meter := range meters {
+		apiMeters = append(apiMeters, toAPIBuildingMeter(meter))
+	}
+	return apiserver.Response(http.StatusOK, apiMeters), nil
+}
+
+// PostBuildingMeter - Creates a building meter
+func (s *EmissionsAPIService) PostBuildingMeter(ctx context.Context, buildingMeter apiserver.BuildingMeter) (apiserver.ImplResponse, error) {
+	return s.upsertBuildingMeter(ctx, 0, buildingMeter, http.StatusCreated)
+}
+
+// GetBuildingMeterById - Get building meter
+func (s *EmissionsAPIService) GetBuildingMeterById(ctx context.Context, buildingMeterId int64) (apiserver.ImplResponse, error) {
+	meter, err := dbhelper.GetBuildingMeter(ctx, buildingMeterId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, toAPIBuildingMeter(meter)), nil
+}
+
+// PutBuildingMeterById - Updates a building meter
+func (s *EmissionsAPIService) PutBuildingMeterById(ctx context.Context, buildingMeterId int64, buildingMeter apiserver.BuildingMeter) (apiserver.ImplResponse, error) {
+	return s.upsertBuildingMeter(ctx, buildingMeterId, buildingMeter, http.StatusOK)
+}
+
+// DeleteBuildingMeterById - Deletes a building meter
+func (s *EmissionsAPIService) DeleteBuildingMeterById(ctx context.Context, buildingMeterId int64) (apiserver.ImplResponse, error) {
+	err := dbhelper.DeleteBuildingMeter(ctx, buildingMeterId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+func (s *EmissionsAPIService) upsertBuildingMeter(ctx context.Context, buildingMeterId int64, buildingMeter apiserver.BuildingMeter, code int) (apiserver.ImplResponse, error) {
+	meter := toAppBuildingMeter(buildingMeter)
+	meter.ID = buildingMeterId
+	if err := validateBuildingMeter(ctx, &meter); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+
+	upserted, err := dbhelper.UpsertBuildingMeter(ctx, meter)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(code, toAPIBuildingMeter(upserted)), nil
+}
+
+// validateBuildingMeter checks the referenced assets and the unit of the meter. The project of the
+// building is filled in, as the emissions asset is created there.
+func validateBuildingMeter(ctx context.Context, meter *appmodel.BuildingMeter) error {
+	zoneAsset, err := dbhelper.GetAssetById(meter.ZoneAssetID)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return fmt.Errorf("zoneAssetId: asset %v is not a zone asset of the app", meter.ZoneAssetID)
+	} else if err != nil {
+		return fmt.Errorf("zoneAssetId: getting zone asset: %v", err)
+	}
+
+	building, err := eliona.GetAsset(meter.BuildingAssetID)
+	if err != nil {
+		return fmt.Errorf("buildingAssetId: getting asset %v: %v", meter.BuildingAssetID, err)
+	}
+	if building.ProjectId != zoneAsset.ProjectID {
+		return fmt.Errorf("zoneAssetId: zone asset is in project %s, the building in project %s", zoneAsset.ProjectID, building.ProjectId)
+	}
+	meter.ProjectID = building.ProjectId
+
+	meters, err := dbhelper.GetBuildingMeters(ctx)
+	if err != nil {
+		return fmt.Errorf("getting building meters: %v", err)
+	}
+	for _, other := range meters {
+		if other.BuildingAssetID == meter.BuildingAssetID && other.ID != meter.ID {
+			return fmt.Errorf("buildingAssetId: building %v already has meter %v", meter.BuildingAssetID, other.ID)
+		}
+	}
+
+	unit := meter.MeterUnit
+	if unit == "" {
+		if unit, err = eliona.GetAttributeUnit(meter.MeterAssetID, meter.MeterAttribute); errors.Is(err, eliona.ErrNotFound) {
+			return fmt.Errorf("meterAttribute: %v", err)
+		} else if err != nil {
+			return fmt.Errorf("meterAssetId: %v", err)
+		}
+	}
+	if _, ok := appmodel.EnergyUnitFactor(unit); !ok {
+		return fmt.Errorf("meterUnit: unit %q of the meter is not an energy unit (Wh, kWh, MWh or GWh)", unit)
+	}
+	return nil
+}
+
+func toAPIBuildingMeter(meter appmodel.BuildingMeter) apiserver.BuildingMeter {
+	apiMeter := apiserver.BuildingMeter{
+		Id:              &meter.ID,
+		BuildingAssetId: meter.BuildingAssetID,
+		ZoneAssetId:     meter.ZoneAssetID,
+		MeterAssetId:    meter.MeterAssetID,
+		MeterAttribute:  meter.MeterAttribute,
+	}
+	if meter.MeterUnit != "" {
+		apiMeter.MeterUnit = &meter.MeterUnit
+	}
+	if meter.AssetID != 0 {
+		apiMeter.EmissionsAssetId = &meter.AssetID
+	}
+	return apiMeter
+}
+
+func toAppBuildingMeter(apiMeter apiserver.BuildingMeter) appmodel.BuildingMeter {
+	meter := appmodel.BuildingMeter{
+		BuildingAssetID: apiMeter.BuildingAssetId,
+		ZoneAssetID:     apiMeter.ZoneAssetId,
+		MeterAssetID:    apiMeter.MeterAssetId,
+		MeterAttribute:  apiMeter.MeterAttribute,
+	}
+	if apiMeter.MeterUnit != nil {
+		meter.MeterUnit = *apiMeter.MeterUnit
+	}
+	return meter
+}
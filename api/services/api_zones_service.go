@@ -0,0 +1,95 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	apiserver "electricity-maps/api/generated"
+	appmodel "electricity-maps/app/model"
+	"electricity-maps/broker"
+	dbhelper "electricity-maps/db/helper"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// ZonesAPIService is a service that implements the logic for the ZonesAPIServicer
+// This service should implement the business logic for every endpoint for the ZonesAPI API.
+// Include any external packages or services that will be required by this service.
+type ZonesAPIService struct {
+}
+
+// NewZonesAPIService creates a default api service
+func NewZonesAPIService() apiserver.ZonesAPIServicer {
+	return &ZonesAPIService{}
+}
+
+// GetZones - List available zones
+func (s *ZonesAPIService) GetZones(ctx context.Context, query string, configId int64) (apiserver.ImplResponse, error) {
+	appConfig, err := getZonesConfig(ctx, configId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	zones, err := broker.NewBudgetedClient(appConfig).Zones(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadGateway}, fmt.Errorf("getting zones: %v", err)
+	}
+
+	result := []apiserver.Zone{}
+	if query == "" {
+		for _, zone := range zones {
+			result = append(result, toAPIZone(zone, nil))
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Code < result[j].Code
+		})
+		return apiserver.Response(http.StatusOK, result), nil
+	}
+
+	for _, match := range broker.RankZones(zones, query) {
+		score := int32(match.Distance)
+		result = append(result, toAPIZone(match.Zone, &score))
+	}
+	return apiserver.Response(http.StatusOK, result), nil
+}
+
+// getZonesConfig returns the configuration with the ID, or the first configuration without one
+func getZonesConfig(ctx context.Context, configId int64) (appmodel.Configuration, error) {
+	if configId != 0 {
+		return dbhelper.GetConfig(ctx, configId)
+	}
+	configs, err := dbhelper.GetConfigs(ctx)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	if len(configs) == 0 {
+		return appmodel.Configuration{}, dbhelper.ErrNotFound
+	}
+	return configs[0], nil
+}
+
+func toAPIZone(zone broker.Zone, score *int32) apiserver.Zone {
+	return apiserver.Zone{
+		Code:   zone.Code,
+		Name:   zone.ZoneName,
+		Access: zone.Access,
+		Score:  score,
+	}
+}
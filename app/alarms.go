@@ -0,0 +1,153 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"electricity-maps/eliona"
+	"fmt"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// evaluateAlarms checks the alarm rules of an asset against the data just written to it. Users
+// are notified when an alarm fires or clears.
+func evaluateAlarms(ctx context.Context, config *appmodel.Configuration, asset appmodel.Asset, data map[string]interface{}, timestamp time.Time) {
+	rules, err := dbhelper.GetAssetAlarmRules(ctx, asset.AssetID)
+	if err != nil {
+		log.Error("dbhelper", "getting alarm rules of asset %v: %v", asset.AssetID, err)
+		return
+	}
+
+	for _, rule := range rules {
+		if !rule.Enable {
+			continue
+		}
+		value, ok := numericValue(data[rule.Attribute])
+		if !ok {
+			// Not every attribute is part of every reading, e.g. forecasts.
+			continue
+		}
+		if err := evaluateAlarm(ctx, config, asset, rule, value, timestamp); err != nil {
+			log.Error("app", "evaluating alarm rule %v of asset %v: %v", rule.ID, asset.AssetID, err)
+		}
+	}
+}
+
+// numericValue converts the value of an attribute to a number. Digital attributes such as
+// grid_signal are written as integers.
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func evaluateAlarm(ctx context.Context, config *appmodel.Configuration, asset appmodel.Asset, rule appmodel.AlarmRule, value float64, timestamp time.Time) error {
+	state, err := dbhelper.GetAlarmState(ctx, rule.ID)
+	if err != nil {
+		return err
+	}
+	next := nextAlarmState(rule, state, value, timestamp)
+	if next.Active == state.Active && next.ViolatingSince.Equal(state.ViolatingSince) {
+		return nil
+	}
+
+	if next.Active != state.Active {
+		log.Info("app", "Alarm rule %v of asset %v changed to active=%t at %s = %v.", rule.ID, asset.AssetID, next.Active, rule.Attribute, value)
+		if err := eliona.NotifyAlarm(config.UserId, asset.ProjectID, alarmMessage(rule, asset, next.Active, value)); err != nil {
+			// The state is stored anyway, so the user is not notified over and over again.
+			log.Error("eliona", "notifying about alarm rule %v: %v", rule.ID, err)
+		}
+	}
+	return dbhelper.UpsertAlarmState(ctx, next)
+}
+
+// nextAlarmState returns the state of an alarm rule after the given value. An alarm fires once the
+// value has been beyond the threshold for the minimum duration and clears only once the value is
+// back by more than the hysteresis.
+func nextAlarmState(rule appmodel.AlarmRule, state appmodel.AlarmState, value float64, timestamp time.Time) appmodel.AlarmState {
+	var violated, cleared bool
+	switch rule.Comparison {
+	case appmodel.AlarmAbove:
+		violated = value > rule.Threshold
+		cleared = value < rule.Threshold-rule.Hysteresis
+	case appmodel.AlarmBelow:
+		violated = value < rule.Threshold
+		cleared = value > rule.Threshold+rule.Hysteresis
+	}
+
+	next := state
+	if state.Active {
+		if cleared {
+			next.Active = false
+			next.ViolatingSince = time.Time{}
+			next.ChangedAt = timestamp
+		}
+		return next
+	}
+
+	if !violated {
+		next.ViolatingSince = time.Time{}
+		return next
+	}
+	if next.ViolatingSince.IsZero() {
+		next.ViolatingSince = timestamp
+	}
+	if timestamp.Sub(next.ViolatingSince) >= time.Duration(rule.MinDurationMinutes)*time.Minute {
+		next.Active = true
+		next.ChangedAt = timestamp
+	}
+	return next
+}
+
+func alarmMessage(rule appmodel.AlarmRule, asset appmodel.Asset, active bool, value float64) api.Translation {
+	if active && rule.Message != "" {
+		return api.Translation{
+			De: api.PtrString(rule.Message),
+			En: api.PtrString(rule.Message),
+		}
+	}
+
+	comparisonDe, comparisonEn := "über", "above"
+	if rule.Comparison == appmodel.AlarmBelow {
+		comparisonDe, comparisonEn = "unter", "below"
+	}
+	if active {
+		return api.Translation{
+			De: api.PtrString(fmt.Sprintf("Electricity Maps: %s der Zone %s ist mit %.1f %s %v.", rule.Attribute, asset.LocationID, value, comparisonDe, rule.Threshold)),
+			En: api.PtrString(fmt.Sprintf("Electricity Maps: %s of zone %s is %.1f, %s %v.", rule.Attribute, asset.LocationID, value, comparisonEn, rule.Threshold)),
+		}
+	}
+	return api.Translation{
+		De: api.PtrString(fmt.Sprintf("Electricity Maps: %s der Zone %s ist mit %.1f nicht mehr %s %v.", rule.Attribute, asset.LocationID, value, comparisonDe, rule.Threshold)),
+		En: api.PtrString(fmt.Sprintf("Electricity Maps: %s of zone %s is back to %.1f, no longer %s %v.", rule.Attribute, asset.LocationID, value, comparisonEn, rule.Threshold)),
+	}
+}
@@ -23,10 +23,12 @@ import (
 	"electricity-maps/broker"
 	dbhelper "electricity-maps/db/helper"
 	"electricity-maps/eliona"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -43,19 +45,76 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
-var appStatus = 0
+// appStatus holds the status of each configuration, shown on the root assets of its projects.
+// Configurations without a status of their own show defaultAppStatus.
+var (
+	appStatus        = make(map[int64]int)
+	defaultAppStatus = statusOK
+	appStatusLock    sync.Mutex
+)
 
 const (
 	statusOK = iota
 	statusError
 	statusFatal
+	statusDegraded
 )
 
-func changeAppStatus(status int) {
-	appStatus = status
+func changeAppStatus(configID int64, status int) {
+	appStatusLock.Lock()
+	appStatus[configID] = status
+	appStatusLock.Unlock()
+	Heartbeat()
+}
+
+// changeAllAppStatus sets the status of all configurations, for errors not caused by a single one.
+func changeAllAppStatus(status int) {
+	appStatusLock.Lock()
+	clear(appStatus)
+	defaultAppStatus = status
+	appStatusLock.Unlock()
 	Heartbeat()
 }
 
+func configAppStatus(configID int64) int {
+	appStatusLock.Lock()
+	defer appStatusLock.Unlock()
+	if status, ok := appStatus[configID]; ok {
+		return status
+	}
+	return defaultAppStatus
+}
+
+// statusSeverity ranks the statuses, so that the worst one of the configurations sharing a root
+// asset is shown.
+var statusSeverity = map[int]int{
+	statusOK:       0,
+	statusDegraded: 1,
+	statusError:    2,
+	statusFatal:    3,
+}
+
+// projectAppStatus is the worst status of the configurations using the project.
+func projectAppStatus(configs []appmodel.Configuration, projectID string) int {
+	status := statusOK
+	found := false
+	for _, config := range configs {
+		if !slices.Contains(config.ProjectIDs, projectID) {
+			continue
+		}
+		found = true
+		if configStatus := configAppStatus(config.Id); statusSeverity[configStatus] > statusSeverity[status] {
+			status = configStatus
+		}
+	}
+	if !found {
+		appStatusLock.Lock()
+		defer appStatusLock.Unlock()
+		return defaultAppStatus
+	}
+	return status
+}
+
 func Initialize() {
 	ctx := context.Background()
 
@@ -63,13 +122,31 @@ func Initialize() {
 	conn := db.NewInitConnectionWithContextAndApplicationName(ctx, app.AppName())
 	defer conn.Close(ctx)
 
+	// Bring the database schema up to date before anything else touches it.
+	if err := dbhelper.Migrate(ctx, conn, "electricity_maps", dbhelper.MigrationsDir); err != nil {
+		log.Fatal("dbhelper", "Migrating database schema: %v", err)
+	}
+
 	// Init the app before the first run.
 	app.Init(conn, app.AppName(),
-		app.ExecSqlFile("db/init.sql"),
 		initAssetCategory(),
 		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
 		dashboard.InitWidgetTypeFiles("resources/widget-types/*.json"),
 	)
+
+	// Bring installations of version 1.0.0 up to date with the asset types, widget types and the
+	// category changed since. Schema changes are versioned migrations in db/migrations and don't
+	// need a patch.
+	app.Patch(conn, app.AppName(), "010100",
+		initAssetCategory(),
+		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
+		dashboard.InitWidgetTypeFiles("resources/widget-types/*.json"),
+	)
+
+	// API keys stored before the encryption or with a rotated encryption key are encrypted anew.
+	if err := dbhelper.EncryptAPIKeys(ctx); err != nil {
+		log.Error("dbhelper", "Encrypting API keys: %v", err)
+	}
 }
 
 func initAssetCategory() func(db.Connection) error {
@@ -97,35 +174,42 @@ func initAssetCategory() func(db.Connection) error {
 }
 
 var (
-	once             sync.Once
-	configChangeChan = make(chan struct{})
-	previousConfigs  = make(map[int64]appmodel.Configuration)
-	configMutex      sync.Mutex
+	once              sync.Once
+	configChangeChans = make(map[int64]chan struct{})
+	previousConfigs   = make(map[int64]appmodel.Configuration)
+	configMutex       sync.Mutex
 )
 
 func CollectData() {
-	config, err := dbhelper.GetConfig(context.Background())
-	if errors.Is(err, dbhelper.ErrNotFound) {
+	configs, err := dbhelper.GetConfigs(context.Background())
+	if err != nil {
+		log.Fatal("dbhelper", "Couldn't read configs from DB: %v", err)
+		changeAllAppStatus(statusFatal)
+		return
+	}
+	if len(configs) == 0 {
 		once.Do(func() {
 			log.Info("dbhelper", "No configs in DB. Please configure the app in Eliona.")
 		})
 		return
 	}
-	if err != nil {
-		log.Fatal("dbhelper", "Couldn't read configs from DB: %v", err)
-		changeAppStatus(statusFatal)
-		return
+
+	// Each configuration is collected in a loop of its own.
+	for _, config := range configs {
+		collectConfig(config)
 	}
+}
 
+func collectConfig(config appmodel.Configuration) {
 	if !config.Enable {
 		if config.Active {
-			dbhelper.SetConfigActiveState(context.Background(), false)
+			dbhelper.SetConfigActiveState(context.Background(), config.Id, false)
 		}
 		return
 	}
 
 	if !config.Active {
-		dbhelper.SetConfigActiveState(context.Background(), true)
+		dbhelper.SetConfigActiveState(context.Background(), config.Id, true)
 		log.Info("dbhelper", "Collecting initialized with Configuration %d:\n"+
 			"Enable: %t\n"+
 			"Refresh Interval: %d\n"+
@@ -141,33 +225,48 @@ func CollectData() {
 	// Check for changes in this specific config
 	if isConfigChanged(config) {
 		select {
-		case configChangeChan <- struct{}{}: // Non-blocking send
+		case configChangeChan(config.Id) <- struct{}{}: // Non-blocking send
 			log.Debug("app", "Config changed signal sent")
 		default:
 			log.Debug("app", "Config change signal not sent, channel full")
 		}
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
 	common.RunOnceWithParam(func(config appmodel.Configuration) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// A config change cancels the context, which also aborts requests still in flight.
+		go func() {
+			select {
+			case <-configChangeChan(config.Id):
+				log.Debug("app", "Config %d changed, cancelling collection", config.Id)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
 		log.Info("main", "Collecting %d started.", config.Id)
-		if err := collectResources(ctx, &config); err != nil {
-			changeAppStatus(statusError)
-			cancel() // Cancel the context to stop the long-running processes
-			return   // Error is handled in the method itself.
+		result, err := collectResources(ctx, &config)
+		if err != nil {
+			if ctx.Err() == nil {
+				changeAppStatus(config.Id, statusError)
+			}
+			return // Error is handled in the method itself.
+		}
+		if result.failed > 0 {
+			log.Warn("main", "Collecting %d finished with %d of %d assets failing.", config.Id, result.failed, result.total)
+		} else {
+			log.Info("main", "Collecting %d finished.", config.Id)
 		}
-		log.Info("main", "Collecting %d finished.", config.Id)
-		changeAppStatus(statusOK)
+		changeAppStatus(config.Id, result.status(config.FailureThreshold))
 
 		// Wait for the next interval or a config change
 		select {
 		case <-time.After(time.Second * time.Duration(config.RefreshInterval)):
 			// Continue with the next iteration
-			return
-		case <-configChangeChan:
+		case <-ctx.Done():
 			// Config changed, restart the process
-			cancel() // Cancel the context to stop the long-running process
-			return
 		}
 	}, config, config.Id)
 }
@@ -192,53 +291,525 @@ func isConfigChanged(newConfig appmodel.Configuration) bool {
 	return false
 }
 
-func triggerReload() {
+// configChangeChan returns the channel signalling changes of a configuration to its collection loop.
+func configChangeChan(configID int64) chan struct{} {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+
+	changeChan, exists := configChangeChans[configID]
+	if !exists {
+		changeChan = make(chan struct{})
+		configChangeChans[configID] = changeChan
+	}
+	return changeChan
+}
+
+func triggerReload(configID int64) {
 	select {
-	case configChangeChan <- struct{}{}:
-		log.Debug("app", "Triggered reload via config change signal")
+	case configChangeChan(configID) <- struct{}{}:
+		log.Debug("app", "Triggered reload of config %d via config change signal", configID)
+	default:
+		log.Debug("app", "Could not trigger reload of config %d, channel full", configID)
+	}
+}
+
+// collectionResult summarizes a collection over all assets of a configuration.
+type collectionResult struct {
+	total  int
+	failed int
+}
+
+// status of the app after the collection. Failing assets degrade the app, from the configured
+// threshold on the app is in error.
+func (r collectionResult) status(failureThreshold int32) int {
+	switch {
+	case r.failed == 0:
+		return statusOK
+	case r.failed*100 >= int(failureThreshold)*r.total:
+		return statusError
 	default:
-		log.Debug("app", "Could not trigger reload, channel full")
+		return statusDegraded
 	}
 }
 
-func collectResources(ctx context.Context, config *appmodel.Configuration) error {
+func collectResources(ctx context.Context, config *appmodel.Configuration) (collectionResult, error) {
 	if err := createRootAsset(config); err != nil {
 		log.Error("app", "creating root asset for config %v in Eliona: %v", config.Id, err)
-		return err
+		return collectionResult{}, err
 	}
 
-	assets, err := dbhelper.GetAssets(ctx)
+	if config.AutoProvision {
+		// Zones of the buildings that could be provisioned are still collected.
+		if err := provisionZoneAssets(ctx, config); err != nil {
+			log.Error("app", "provisioning zone assets for config %v: %v", config.Id, err)
+		}
+	}
+
+	assets, err := dbhelper.GetAssets(ctx, config.Id)
 	if err != nil {
 		log.Error("dbhelper", "getting assets: %v", err)
-		return err
+		return collectionResult{}, err
+	}
+	// Assets sharing a zone are served by a single fetch of the zone.
+	assetsByZone := make(map[string][]appmodel.Asset)
+	for _, asset := range assets {
+		assetsByZone[asset.LocationID] = append(assetsByZone[asset.LocationID], asset)
+	}
+
+	brokerClient := broker.NewBudgetedClient(*config)
+	result := collectionResult{total: len(assets)}
+	var resultMutex sync.Mutex
+	var wg sync.WaitGroup
+	zones := make(chan string)
+	for range max(config.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for zone := range zones {
+				failed := collectZone(ctx, config, brokerClient, zone, assetsByZone[zone])
+				resultMutex.Lock()
+				result.failed += failed
+				resultMutex.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for zone := range assetsByZone {
+		select {
+		case zones <- zone:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(zones)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	purgeZoneReadings(ctx, config)
+	return result, nil
+}
+
+// purgeZoneReadings removes the readings of the configuration older than its retention. Readings
+// the emissions of building meters are still to be calculated from are kept.
+func purgeZoneReadings(ctx context.Context, config *appmodel.Configuration) {
+	if config.HistoryRetentionDays <= 0 {
+		return // Kept forever
+	}
+	before := time.Now().AddDate(0, 0, -int(config.HistoryRetentionDays))
+
+	assets, err := dbhelper.GetAssets(ctx, config.Id)
+	if err != nil {
+		log.Error("dbhelper", "getting assets to purge zone readings: %v", err)
+		return
+	}
+	zoneAssets := make(map[int32]bool, len(assets))
+	for _, asset := range assets {
+		zoneAssets[asset.AssetID] = true
+	}
+	meters, err := dbhelper.GetBuildingMeters(ctx)
+	if err != nil {
+		log.Error("dbhelper", "getting building meters to purge zone readings: %v", err)
+		return
 	}
+	for _, meter := range meters {
+		if !zoneAssets[meter.ZoneAssetID] {
+			continue
+		}
+		pending := meter.EmissionsUntil
+		if pending.IsZero() {
+			pending = time.Now().Truncate(time.Hour).Add(-emissionsStartHours * time.Hour)
+		}
+		if pending.Before(before) {
+			before = pending
+		}
+	}
+
+	if deleted, err := dbhelper.DeleteZoneReadingsBefore(ctx, config.Id, before); err != nil {
+		log.Error("dbhelper", "purging zone readings: %v", err)
+	} else if deleted > 0 {
+		log.Debug("app", "purged %d zone readings older than %v", deleted, before)
+	}
+}
+
+// storeZoneReadings keeps the readings fetched with the configuration in the app's history.
+func storeZoneReadings(ctx context.Context, configID int64, infos ...broker.ZoneData) error {
+	readings := make([]appmodel.ZoneReading, 0, len(infos))
+	for _, info := range infos {
+		data, err := json.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("marshalling reading of zone %s: %v", info.Zone, err)
+		}
+		readings = append(readings, appmodel.ZoneReading{
+			ConfigurationID:       configID,
+			Zone:                  info.Zone,
+			Datetime:              info.Datetime,
+			CarbonIntensity:       info.CarbonIntensity,
+			RenewablePercentage:   info.RenewablePercentage,
+			FossilFreePercentage:  info.FossilFreePercentage,
+			PowerConsumptionTotal: info.PowerConsumptionTotal,
+			PowerProductionTotal:  info.PowerProductionTotal,
+			PowerImportTotal:      info.PowerImportTotal,
+			PowerExportTotal:      info.PowerExportTotal,
+			IsEstimated:           info.IsEstimated,
+			Data:                  string(data),
+		})
+	}
+	return dbhelper.UpsertZoneReadings(ctx, readings)
+}
+
+// provisionZoneAssets creates an Electricity Zone asset for every zone the buildings of the
+// configured projects are located in, unless the project already has an asset for the zone. The
+// zone asset is placed below the first building found in the zone, all buildings in the zone are
+// linked to it. Where a building is located is kept, so that it costs an API request only until
+// its coordinates change.
+func provisionZoneAssets(ctx context.Context, config *appmodel.Configuration) error {
+	assets, err := dbhelper.GetAssets(ctx, config.Id)
+	if err != nil {
+		return fmt.Errorf("getting assets: %v", err)
+	}
+	existing := make(map[string]bool)
 	for _, asset := range assets {
-		electricityInfo, err := broker.GetZoneData(asset.LocationID, config.ApiKey)
+		existing[asset.ProjectID+"/"+asset.LocationID] = true
+	}
+	stored, err := dbhelper.GetBuildingZones(ctx, config.Id)
+	if err != nil {
+		return fmt.Errorf("getting building zones: %v", err)
+	}
+	buildingZones := make(map[int32]appmodel.BuildingZone, len(stored))
+	for _, buildingZone := range stored {
+		buildingZones[buildingZone.BuildingAssetID] = buildingZone
+	}
+
+	brokerClient := broker.NewBudgetedClient(*config)
+	for _, projectID := range config.ProjectIDs {
+		buildings, err := eliona.GetProjectAssets(projectID, config.BuildingAssetType)
 		if err != nil {
-			log.Error("broker", "getting electricityInfo data: %v", err)
-			return err
+			return fmt.Errorf("getting buildings of project %s: %v", projectID, err)
 		}
-		electricityInfoMap := electricityInfoToMap(electricityInfo)
-		if err := eliona.UpsertData(asset.AssetID, electricityInfoMap, time.Now(), api.SUBTYPE_INPUT); err != nil {
-			log.Error("eliona", "upserting data for asset %v: %v", asset.AssetID, err)
-			return err
+
+		var zoneAssets []asset.AssetWithParentReferences
+		for _, building := range buildings {
+			lat, lon, ok, err := eliona.GetCoordinates(&building)
+			if err != nil {
+				log.Warn("eliona", "getting coordinates of building %v: %v", building.GetId(), err)
+				continue
+			}
+			if !ok {
+				log.Debug("app", "building %v has no coordinates to provision a zone asset for", building.GetId())
+				continue
+			}
+
+			buildingZone, located := buildingZones[building.GetId()]
+			if !located || buildingZone.Latitude != lat || buildingZone.Longitude != lon {
+				buildingZone = appmodel.BuildingZone{BuildingAssetID: building.GetId(), Latitude: lat, Longitude: lon}
+				zone, err := brokerClient.LocateByCoordinates(ctx, lat, lon)
+				if errors.Is(err, broker.ErrNotFound) {
+					log.Info("app", "No zone found at coordinates %v, %v of building %v.", lat, lon, building.GetId())
+				} else if ctx.Err() != nil {
+					return ctx.Err()
+				} else if err != nil {
+					// Not stored, so it is tried again in the next collection.
+					log.Warn("app", "locating building %v: %v", building.GetId(), err)
+					continue
+				} else {
+					buildingZone.Zone, buildingZone.ZoneName = zone.Code, zone.ZoneName
+				}
+				if err := dbhelper.UpsertBuildingZone(ctx, config.Id, buildingZone); err != nil {
+					log.Error("dbhelper", "storing zone of building %v: %v", building.GetId(), err)
+				}
+				buildingZones[building.GetId()] = buildingZone
+			}
+			if buildingZone.Zone == "" {
+				continue
+			}
+
+			key := projectID + "/" + buildingZone.Zone
+			if existing[key] {
+				continue
+			}
+			existing[key] = true
+			zoneAssets = append(zoneAssets, &eliona.Zone{
+				Code:        buildingZone.Zone,
+				Name:        buildingZone.ZoneName,
+				BuildingGAI: building.GlobalAssetIdentifier,
+				ConfigID:    config.Id,
+			})
+		}
+
+		if len(zoneAssets) > 0 {
+			if err := eliona.CreateProjectAssets(*config, projectID, zoneAssets); err != nil {
+				return fmt.Errorf("creating zone assets in project %s: %v", projectID, err)
+			}
+			log.Info("app", "Provisioned %d zone assets in project %s.", len(zoneAssets), projectID)
+		}
+		if err := linkBuildings(ctx, config, projectID, buildings, buildingZones); err != nil {
+			return fmt.Errorf("linking buildings of project %s: %v", projectID, err)
+		}
+	}
+	return nil
+}
+
+// linkBuildings links the buildings of the project to the zone asset of their zone. A zone asset
+// lists the names of the buildings linked to it in its buildings property, which is written
+// whenever a building is linked anew.
+func linkBuildings(ctx context.Context, config *appmodel.Configuration, projectID string, buildings []api.Asset, buildingZones map[int32]appmodel.BuildingZone) error {
+	assets, err := dbhelper.GetAssets(ctx, config.Id)
+	if err != nil {
+		return fmt.Errorf("getting assets: %v", err)
+	}
+	zoneAssetIDs := make(map[string]int32)
+	for _, asset := range assets {
+		if asset.ProjectID == projectID {
+			zoneAssetIDs[asset.LocationID] = asset.AssetID
 		}
 	}
 
+	buildingNames := make(map[int32][]string)
+	var unlinked []appmodel.BuildingZone
+	for _, building := range buildings {
+		buildingZone, ok := buildingZones[building.GetId()]
+		if !ok || buildingZone.Zone == "" {
+			continue
+		}
+		zoneAssetID, ok := zoneAssetIDs[buildingZone.Zone]
+		if !ok {
+			continue
+		}
+		buildingNames[zoneAssetID] = append(buildingNames[zoneAssetID], building.GetName())
+		if buildingZone.ZoneAssetID != zoneAssetID {
+			buildingZone.ZoneAssetID = zoneAssetID
+			unlinked = append(unlinked, buildingZone)
+		}
+	}
+
+	// The links are stored only once the property is written, so that a failing write is retried.
+	written := make(map[int32]bool)
+	for _, buildingZone := range unlinked {
+		if written[buildingZone.ZoneAssetID] {
+			continue
+		}
+		names := buildingNames[buildingZone.ZoneAssetID]
+		slices.Sort(names)
+		if err := eliona.UpsertData(buildingZone.ZoneAssetID, map[string]any{"buildings": strings.Join(names, ", ")}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+			return fmt.Errorf("writing buildings of zone asset %v: %v", buildingZone.ZoneAssetID, err)
+		}
+		written[buildingZone.ZoneAssetID] = true
+	}
+	for _, buildingZone := range unlinked {
+		if err := dbhelper.SetBuildingZoneAsset(ctx, config.Id, buildingZone.BuildingAssetID, buildingZone.ZoneAssetID); err != nil {
+			return err
+		}
+		buildingZones[buildingZone.BuildingAssetID] = buildingZone
+	}
 	return nil
 }
 
-func createRootAsset(config *appmodel.Configuration) error {
-	if hasRoot, err := dbhelper.RootAssetAlreadyCreated(); err != nil {
-		return fmt.Errorf("finding whether config already has root asset: %v", err)
-	} else if hasRoot {
+// collectZone fetches the data of a zone once and writes it to all assets mapped to the zone. Each
+// asset is handled on its own, so that one failing asset does not stall the others. Returns the
+// number of failed assets.
+func collectZone(ctx context.Context, config *appmodel.Configuration, brokerClient *broker.Client, zone string, assets []appmodel.Asset) (failed int) {
+	electricityInfo, zoneErr := brokerClient.GetZoneData(ctx, zone)
+	if zoneErr == nil && config.MarginalSignal {
+		// Like forecasts, the marginal signal is not part of every API plan.
+		marginal, err := brokerClient.GetMarginalCarbonIntensity(ctx, zone)
+		if err != nil {
+			log.Warn("broker", "getting marginal carbon intensity for zone %s: %v", zone, err)
+		} else {
+			electricityInfo.MarginalCarbonIntensity = &marginal
+		}
+	}
+	if zoneErr != nil {
+		log.Error("broker", "getting electricityInfo data for zone %s: %v", zone, zoneErr)
+		zoneErr = fmt.Errorf("getting data of zone %s: %v", zone, zoneErr)
+	} else if err := storeZoneReadings(ctx, config.Id, electricityInfo); err != nil {
+		// The history is a by-product, the assets are still served.
+		log.Error("dbhelper", "storing reading of zone %s: %v", zone, err)
+	}
+
+	var forecastMap map[string]interface{}
+	var schedulingMap map[string]interface{}
+	now := time.Now()
+	if zoneErr == nil {
+		forecast, cached := cachedForecast(config.Id, zone)
+		if !cached || slices.ContainsFunc(assets, func(asset appmodel.Asset) bool {
+			return electricityInfo.Datetime.After(asset.LastDatetime)
+		}) {
+			// Forecasts are not part of every API plan, so the current values are written even without one.
+			fetched, err := brokerClient.GetZoneForecast(ctx, zone)
+			if err != nil {
+				log.Warn("broker", "getting forecast for zone %s: %v", zone, err)
+				forecast = nil
+			} else {
+				forecast = &fetched
+				forecastMap = forecastToMap(fetched, electricityInfo.Datetime)
+			}
+			cacheForecast(config.Id, zone, forecast)
+		}
+		// The scheduling signals depend on the time of day, so they are updated every cycle.
+		if forecast != nil {
+			schedulingMap = schedulingToMap(config, *forecast, electricityInfo.CarbonIntensity, now)
+		}
+	}
+
+	for _, asset := range assets {
+		err := zoneErr
+		if err == nil {
+			err = writeAssetData(ctx, config, asset, electricityInfo, forecastMap)
+		}
+		if err == nil && len(schedulingMap) > 0 {
+			err = writeSchedulingData(ctx, config, asset, schedulingMap, now)
+		}
+		if ctx.Err() != nil {
+			return failed
+		}
+		if err != nil {
+			failed++
+		}
+		recordAssetStatus(ctx, asset, err)
+		if err := calculateEmissions(ctx, config, asset); err != nil {
+			log.Error("app", "calculating emissions for asset %v: %v", asset.AssetID, err)
+		}
+	}
+	return failed
+}
+
+func writeAssetData(ctx context.Context, config *appmodel.Configuration, asset appmodel.Asset, electricityInfo broker.ZoneData, forecastMap map[string]interface{}) error {
+	if !electricityInfo.Datetime.After(asset.LastDatetime) {
+		log.Debug("app", "data of zone %s for %v already written to asset %v", asset.LocationID, electricityInfo.Datetime, asset.AssetID)
 		return nil
 	}
-	var assets []asset.AssetWithParentReferences
-	root := eliona.Root{Config: config}
-	assets = append(assets, &root)
-	if err := eliona.CreateAssets(*config, assets); err != nil {
-		return fmt.Errorf("creating assets: %v", err)
+	electricityInfoMap := electricityInfoToMap(electricityInfo)
+	for name, value := range forecastMap {
+		electricityInfoMap[name] = value
+	}
+
+	if err := eliona.UpsertData(asset.AssetID, electricityInfoMap, electricityInfo.Datetime, api.SUBTYPE_INPUT); err != nil {
+		log.Error("eliona", "upserting data for asset %v: %v", asset.AssetID, err)
+		return fmt.Errorf("writing data to Eliona: %v", err)
+	}
+	evaluateAlarms(ctx, config, asset, electricityInfoMap, electricityInfo.Datetime)
+	if err := collectFlows(ctx, config, asset, electricityInfo); err != nil {
+		log.Error("app", "collecting cross-border flows for asset %v: %v", asset.AssetID, err)
+		return fmt.Errorf("collecting cross-border flows: %v", err)
+	}
+	if err := dbhelper.SetAssetLastDatetime(ctx, asset.AssetID, electricityInfo.Datetime); err != nil {
+		log.Error("dbhelper", "setting last datetime for asset %v: %v", asset.AssetID, err)
+		return fmt.Errorf("storing last datetime: %v", err)
+	}
+	return nil
+}
+
+// writeSchedulingData writes the carbon-aware scheduling signals of the zone to the asset.
+func writeSchedulingData(ctx context.Context, config *appmodel.Configuration, asset appmodel.Asset, schedulingMap map[string]interface{}, now time.Time) error {
+	if err := eliona.UpsertData(asset.AssetID, schedulingMap, now, api.SUBTYPE_INPUT); err != nil {
+		log.Error("eliona", "upserting scheduling signals for asset %v: %v", asset.AssetID, err)
+		return fmt.Errorf("writing scheduling signals to Eliona: %v", err)
+	}
+	evaluateAlarms(ctx, config, asset, schedulingMap, now)
+	return nil
+}
+
+// recordAssetStatus stores the outcome of collecting an asset and shows it on the asset in Eliona.
+func recordAssetStatus(ctx context.Context, asset appmodel.Asset, collectErr error) {
+	var lastError *string
+	status := map[string]any{"collection_status": statusOK, "collection_error": ""}
+	if collectErr != nil {
+		lastError = common.Ptr(collectErr.Error())
+		status = map[string]any{"collection_status": statusError, "collection_error": collectErr.Error()}
+	}
+
+	if err := dbhelper.SetAssetLastError(ctx, asset.AssetID, lastError); err != nil {
+		log.Error("dbhelper", "setting last error for asset %v: %v", asset.AssetID, err)
+	}
+	if err := eliona.UpsertData(asset.AssetID, status, time.Now(), api.SUBTYPE_STATUS); err != nil {
+		log.Error("eliona", "upserting collection status for asset %v: %v", asset.AssetID, err)
+	}
+}
+
+// collectFlows writes the cross-border flows of a zone to child assets of the zone asset, one per
+// neighbouring zone. Missing child assets are created on the fly.
+func collectFlows(ctx context.Context, config *appmodel.Configuration, zoneAsset appmodel.Asset, info broker.ZoneData) error {
+	flows, err := dbhelper.GetFlows(ctx, zoneAsset.AssetID)
+	if err != nil {
+		return fmt.Errorf("getting flows: %v", err)
+	}
+	flowAssetIDs := make(map[string]int32)
+	for _, flow := range flows {
+		flowAssetIDs[flow.NeighbourZone] = flow.AssetID
+	}
+
+	neighbours := make(map[string]struct{})
+	for neighbour := range info.PowerImportBreakdown {
+		neighbours[neighbour] = struct{}{}
+	}
+	for neighbour := range info.PowerExportBreakdown {
+		neighbours[neighbour] = struct{}{}
+	}
+
+	var zoneGAI string
+	var missing []asset.AssetWithParentReferences
+	for neighbour := range neighbours {
+		if _, ok := flowAssetIDs[neighbour]; ok {
+			continue
+		}
+		if zoneGAI == "" {
+			// Needed only to reference the zone asset as parent.
+			elionaAsset, err := eliona.GetAsset(zoneAsset.AssetID)
+			if err != nil {
+				return fmt.Errorf("getting zone asset %v: %v", zoneAsset.AssetID, err)
+			}
+			zoneGAI = elionaAsset.GlobalAssetIdentifier
+		}
+		missing = append(missing, &eliona.Flow{
+			Zone:          zoneAsset.LocationID,
+			NeighbourZone: neighbour,
+			ZoneAssetID:   zoneAsset.AssetID,
+			ZoneGAI:       zoneGAI,
+		})
+	}
+	if len(missing) > 0 {
+		if err := eliona.CreateProjectAssets(*config, zoneAsset.ProjectID, missing); err != nil {
+			return fmt.Errorf("creating flow assets: %v", err)
+		}
+		if flows, err = dbhelper.GetFlows(ctx, zoneAsset.AssetID); err != nil {
+			return fmt.Errorf("getting created flows: %v", err)
+		}
+		for _, flow := range flows {
+			flowAssetIDs[flow.NeighbourZone] = flow.AssetID
+		}
+	}
+
+	for neighbour := range neighbours {
+		imported := info.PowerImportBreakdown[neighbour]
+		exported := info.PowerExportBreakdown[neighbour]
+		if err := eliona.UpsertData(flowAssetIDs[neighbour], map[string]any{
+			"import":     imported,
+			"export":     exported,
+			"net_import": imported - exported,
+		}, info.Datetime, api.SUBTYPE_INPUT); err != nil {
+			return fmt.Errorf("upserting flow to %s: %v", neighbour, err)
+		}
+	}
+	return nil
+}
+
+// createRootAsset creates the root asset in the projects of the configuration that have none yet.
+// Configurations using the same project share its root asset.
+func createRootAsset(config *appmodel.Configuration) error {
+	for _, projectID := range config.ProjectIDs {
+		if _, err := dbhelper.GetProjectRootAsset(context.Background(), projectID); err == nil {
+			continue
+		} else if !errors.Is(err, dbhelper.ErrNotFound) {
+			return fmt.Errorf("finding whether project %s already has root asset: %v", projectID, err)
+		}
+		root := eliona.Root{Config: config}
+		if err := eliona.CreateProjectAssets(*config, projectID, []asset.AssetWithParentReferences{&root}); err != nil {
+			return fmt.Errorf("creating assets: %v", err)
+		}
 	}
 	return nil
 }
@@ -247,8 +818,46 @@ func electricityInfoToMap(info broker.ZoneData) map[string]interface{} {
 	attrMap := make(map[string]interface{})
 	attrMap["name"] = info.Zone
 	attrMap["carbon_intensity"] = info.CarbonIntensity
+	// The variant is written separately as well, so that switching it does not mix up trends.
+	switch info.EmissionFactorType {
+	case "lifecycle", "direct":
+		attrMap["carbon_intensity_"+info.EmissionFactorType] = info.CarbonIntensity
+	}
+	if info.MarginalCarbonIntensity != nil {
+		attrMap["carbon_intensity_marginal"] = *info.MarginalCarbonIntensity
+	}
 	attrMap["renewable_percentage"] = info.RenewablePercentage
 	attrMap["fossil_free_percentage"] = info.FossilFreePercentage
+	attrMap["power_production_total"] = info.PowerProductionTotal
+	attrMap["power_consumption_total"] = info.PowerConsumptionTotal
+	attrMap["power_import_total"] = info.PowerImportTotal
+	attrMap["power_export_total"] = info.PowerExportTotal
+	// Sources without data in the zone are omitted instead of being reported as zero.
+	for source, value := range info.PowerProductionBreakdown.Sources() {
+		if value != nil {
+			attrMap["production_"+source] = *value
+		}
+	}
+	for source, value := range info.PowerConsumptionBreakdown.Sources() {
+		if value != nil {
+			attrMap["consumption_"+source] = *value
+		}
+	}
+	return attrMap
+}
+
+// forecastHorizons are the look-ahead times written as forecast attributes.
+var forecastHorizons = []int{1, 6, 12, 24, 48, 72}
+
+func forecastToMap(forecast broker.ZoneForecast, now time.Time) map[string]interface{} {
+	attrMap := make(map[string]interface{})
+	for _, hours := range forecastHorizons {
+		point, ok := forecast.At(now.Add(time.Duration(hours) * time.Hour))
+		if !ok {
+			continue
+		}
+		attrMap[fmt.Sprintf("carbon_intensity_forecast_%dh", hours)] = point.CarbonIntensity
+	}
 	return attrMap
 }
 
@@ -258,7 +867,7 @@ func ListenForOutputChanges() {
 		outputs, err := eliona.ListenForPropertyChanges()
 		if err != nil {
 			log.Error("eliona", "listening for output changes: %v", err)
-			changeAppStatus(statusError)
+			changeAllAppStatus(statusError)
 			return
 		}
 
@@ -270,16 +879,15 @@ func ListenForOutputChanges() {
 			asset, err := dbhelper.GetAssetById(output.AssetId)
 			if errors.Is(err, dbhelper.ErrNotFound) {
 				handleNewAsset(output)
-				triggerReload()
 				continue
 			} else if err != nil {
 				log.Error("dbhelper", "getting asset by assetID %v: %v", output.AssetId, err)
-				changeAppStatus(statusError)
+				changeAllAppStatus(statusError)
 				return
 			}
 
 			handleExistingAsset(output, asset)
-			triggerReload()
+			triggerReload(asset.ConfigurationID)
 		}
 
 		time.Sleep(time.Second * 5)
@@ -305,42 +913,63 @@ func handleNewAsset(output api.Data) {
 		return
 	}
 
-	config, err := dbhelper.GetConfig(context.Background())
-	if err != nil {
+	config, err := projectConfig(context.Background(), elionaAsset.ProjectId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		log.Info("app", "No configuration collects project %s of asset %v.", elionaAsset.ProjectId, elionaAsset.GetId())
+		return
+	} else if err != nil {
 		log.Error("dbhelper", "getting config: %v", err)
-		changeAppStatus(statusError)
+		changeAllAppStatus(statusError)
 		return
 	}
 
-	location, err := broker.Locate(config, locationName)
-	if errors.Is(err, broker.ErrNotFound) {
-		zones, _ := broker.ListAvailableZones(config.ApiKey)
-		msg := "Location not found. Available:"
-		for code, zone := range zones {
-			msg += fmt.Sprintf(" %s(%s),", zone.ZoneName, code)
+	var location broker.Zone
+	if isAutoLocation(locationName) {
+		location, ok = locateZoneByCoordinates(config, elionaAsset)
+		if !ok {
+			return
+		}
+	} else {
+		location, ok = locateZone(config, elionaAsset.GetId(), locationName)
+		if !ok {
+			return
 		}
-		msg = strings.TrimSuffix(msg, ",")
-		_ = eliona.UpsertData(elionaAsset.GetId(), map[string]any{"name": msg}, time.Now(), api.SUBTYPE_PROPERTY)
-		return
-	} else if err != nil {
-		log.Warn("app", "trying to locate %s: %v", locationName, err)
-		return
-	}
 
-	locationNameFormatted := formatLocationName(location)
+		locationNameFormatted := formatLocationName(location)
 
-	if err := eliona.UpsertData(elionaAsset.GetId(), map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
-		log.Error("eliona", "updating asset %v location name: %v", elionaAsset.GetId(), err)
-		return
+		if err := eliona.UpsertData(elionaAsset.GetId(), map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+			log.Error("eliona", "updating asset %v location name: %v", elionaAsset.GetId(), err)
+			return
+		}
 	}
 
 	if err := dbhelper.InsertAsset(client.AuthenticationContext(), appmodel.Asset{
-		ProjectID:  elionaAsset.ProjectId,
-		AssetID:    elionaAsset.GetId(),
-		LocationID: location.Code,
+		ConfigurationID: config.Id,
+		ProjectID:       elionaAsset.ProjectId,
+		AssetID:         elionaAsset.GetId(),
+		LocationID:      location.Code,
 	}); err != nil {
 		log.Error("dbhelper", "inserting asset: %v", err)
+		return
 	}
+	triggerReload(config.Id)
+
+	go backfillAsset(config, elionaAsset.GetId(), location.Code)
+}
+
+// projectConfig returns the configuration collecting a project. If several configurations share
+// the project, the first one takes new assets.
+func projectConfig(ctx context.Context, projectID string) (appmodel.Configuration, error) {
+	configs, err := dbhelper.GetConfigs(ctx)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	for _, config := range configs {
+		if slices.Contains(config.ProjectIDs, projectID) {
+			return config, nil
+		}
+	}
+	return appmodel.Configuration{}, dbhelper.ErrNotFound
 }
 
 func handleExistingAsset(output api.Data, asset appmodel.Asset) {
@@ -351,32 +980,40 @@ func handleExistingAsset(output api.Data, asset appmodel.Asset) {
 		return
 	}
 
-	config, err := dbhelper.GetConfig(context.Background())
+	config, err := dbhelper.GetConfig(context.Background(), asset.ConfigurationID)
 	if err != nil {
-		log.Error("dbhelper", "getting config: %v", err)
-		changeAppStatus(statusError)
+		log.Error("dbhelper", "getting config %d: %v", asset.ConfigurationID, err)
+		changeAppStatus(asset.ConfigurationID, statusError)
 		return
 	}
 
-	location, err := broker.Locate(config, locationName)
-	if errors.Is(err, broker.ErrNotFound) {
-		zones, _ := broker.ListAvailableZones(config.ApiKey)
-		msg := "Location not found. Available:"
-		for code, zone := range zones {
-			msg += fmt.Sprintf(" %s(%s),", zone.ZoneName, code)
+	var location broker.Zone
+	if isAutoLocation(locationName) {
+		elionaAsset, err := eliona.GetAsset(asset.AssetID)
+		if err != nil {
+			log.Error("eliona", "getting asset ID %v: %v", asset.AssetID, err)
+			return
+		}
+		location, ok = locateZoneByCoordinates(config, elionaAsset)
+		if !ok {
+			return
+		}
+	} else {
+		location, ok = locateZone(config, asset.AssetID, locationName)
+		if !ok {
+			return
 		}
-		msg = strings.TrimSuffix(msg, ",")
-		_ = eliona.UpsertData(asset.AssetID, map[string]any{"name": msg}, time.Now(), api.SUBTYPE_PROPERTY)
-		return
-	} else if err != nil {
-		log.Warn("app", "trying to locate %s: %v", locationName, err)
-		return
-	}
 
-	locationNameFormatted := formatLocationName(location)
+		locationNameFormatted := formatLocationName(location)
 
-	if err := eliona.UpsertData(asset.AssetID, map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
-		log.Error("eliona", "updating asset %v location name: %v", asset.AssetID, err)
+		if err := eliona.UpsertData(asset.AssetID, map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+			log.Error("eliona", "updating asset %v location name: %v", asset.AssetID, err)
+			return
+		}
+	}
+
+	// Saving the properties without changing the zone must not make the current hour written again.
+	if location.Code == asset.LocationID {
 		return
 	}
 
@@ -385,7 +1022,133 @@ func handleExistingAsset(output api.Data, asset appmodel.Asset) {
 		LocationID: location.Code,
 	}); err != nil {
 		log.Error("dbhelper", "updating asset: %v", err)
+		return
+	}
+
+	go backfillAsset(config, asset.AssetID, location.Code)
+}
+
+// Values of the zone_match_status attribute
+const (
+	zoneMatched       = 0
+	zoneNotFound      = 1
+	zoneAmbiguous     = 2
+	zoneNotAccessible = 3
+)
+
+// zoneMatchCandidates is the number of candidates reported if no zone matches confidently
+const zoneMatchCandidates = 5
+
+// locateZone finds the zone the user entered for an asset and reports the outcome in the asset's
+// zone match attributes. The user's input is left untouched unless a zone matches confidently.
+func locateZone(config appmodel.Configuration, assetID int32, locationName string) (broker.Zone, bool) {
+	brokerClient := broker.NewBudgetedClient(config)
+	location, err := brokerClient.Locate(context.Background(), locationName)
+	if err == nil && !location.Accessible() {
+		// Collecting the zone would fail on every cycle.
+		log.Info("app", "Zone %s of asset %v is not included in the API plan.", location.Code, assetID)
+		recordZoneMatch(assetID, zoneNotAccessible, []broker.ZoneMatch{{Zone: location}})
+		return broker.Zone{}, false
+	}
+	if err == nil {
+		recordZoneMatch(assetID, zoneMatched, []broker.ZoneMatch{{Zone: location}})
+		return location, true
+	}
+	if !errors.Is(err, broker.ErrNotFound) && !errors.Is(err, broker.ErrAmbiguous) {
+		log.Warn("app", "trying to locate %s: %v", locationName, err)
+		return broker.Zone{}, false
+	}
+
+	candidates, cErr := brokerClient.Candidates(context.Background(), locationName, zoneMatchCandidates)
+	if cErr != nil {
+		log.Warn("app", "getting zone candidates for %s: %v", locationName, cErr)
+	}
+	status := zoneNotFound
+	if errors.Is(err, broker.ErrAmbiguous) {
+		status = zoneAmbiguous
+	}
+	log.Info("app", "Zone %q of asset %v could not be matched: %v", locationName, assetID, err)
+	recordZoneMatch(assetID, status, candidates)
+	return broker.Zone{}, false
+}
+
+// isAutoLocation reports whether the zone should be detected from the asset's coordinates
+func isAutoLocation(locationName string) bool {
+	locationName = strings.TrimSpace(locationName)
+	return locationName == "" || strings.EqualFold(locationName, "auto")
+}
+
+// locateZoneByCoordinates finds the zone of an asset from its own coordinates or those of its
+// locational parents. The zone is detected only when the asset's properties change; moving the
+// asset does not detect it anew.
+func locateZoneByCoordinates(config appmodel.Configuration, elionaAsset *api.Asset) (broker.Zone, bool) {
+	lat, lon, ok, err := eliona.GetCoordinates(elionaAsset)
+	if err != nil {
+		log.Error("eliona", "getting coordinates of asset %v: %v", elionaAsset.GetId(), err)
+		return broker.Zone{}, false
 	}
+	if !ok {
+		log.Info("app", "Neither asset %v nor its locational parents have coordinates to detect the zone from.", elionaAsset.GetId())
+		recordZoneMatch(elionaAsset.GetId(), zoneNotFound, nil)
+		return broker.Zone{}, false
+	}
+
+	location, err := broker.NewBudgetedClient(config).LocateByCoordinates(context.Background(), lat, lon)
+	if errors.Is(err, broker.ErrNotFound) {
+		log.Info("app", "No zone found at coordinates %v, %v of asset %v.", lat, lon, elionaAsset.GetId())
+		recordZoneMatch(elionaAsset.GetId(), zoneNotFound, nil)
+		return broker.Zone{}, false
+	} else if err != nil {
+		log.Warn("app", "trying to locate asset %v at %v, %v: %v", elionaAsset.GetId(), lat, lon, err)
+		return broker.Zone{}, false
+	}
+
+	recordZoneMatch(elionaAsset.GetId(), zoneMatched, []broker.ZoneMatch{{Zone: location}})
+	return location, true
+}
+
+func recordZoneMatch(assetID int32, status int, candidates []broker.ZoneMatch) {
+	formatted := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if status == zoneMatched || status == zoneNotAccessible {
+			formatted = append(formatted, formatLocationName(candidate.Zone))
+			continue
+		}
+		formatted = append(formatted, fmt.Sprintf("%s (score %d)", formatLocationName(candidate.Zone), candidate.Distance))
+	}
+	data := map[string]any{
+		"zone_match_status":     status,
+		"zone_match_candidates": strings.Join(formatted, "; "),
+	}
+	if err := eliona.UpsertData(assetID, data, time.Now(), api.SUBTYPE_STATUS); err != nil {
+		log.Error("eliona", "upserting zone match status for asset %v: %v", assetID, err)
+	}
+}
+
+// backfillAsset writes the past data of a zone to a freshly mapped asset, so that it does not
+// start out empty.
+func backfillAsset(config appmodel.Configuration, assetID int32, locationID string) {
+	if config.BackfillHours <= 0 {
+		return
+	}
+
+	history, err := broker.NewBudgetedClient(config).GetZoneHistory(context.Background(), locationID, int(config.BackfillHours))
+	if err != nil {
+		log.Error("broker", "getting history of zone %s for asset %v: %v", locationID, assetID, err)
+		return
+	}
+
+	if err := storeZoneReadings(context.Background(), config.Id, history...); err != nil {
+		log.Error("dbhelper", "storing history of zone %s: %v", locationID, err)
+	}
+
+	for _, electricityInfo := range history {
+		if err := eliona.UpsertData(assetID, electricityInfoToMap(electricityInfo), electricityInfo.Datetime, api.SUBTYPE_INPUT); err != nil {
+			log.Error("eliona", "upserting history data for asset %v: %v", assetID, err)
+			return
+		}
+	}
+	log.Info("app", "Backfilled %d hours of zone %s for asset %v.", len(history), locationID, assetID)
 }
 
 func getLocationName(data map[string]interface{}) (string, bool) {
@@ -414,6 +1177,27 @@ func outputData(asset appmodel.Asset, data map[string]interface{}) error {
 	return nil
 }
 
+// RefreshZoneCatalogue keeps the cached zone catalogue up to date. The catalogue is fetched from
+// the API only if it is outdated.
+func RefreshZoneCatalogue() {
+	configs, err := dbhelper.GetConfigs(context.Background())
+	if err != nil {
+		log.Error("dbhelper", "reading configs: %v", err)
+		return
+	}
+
+	for _, config := range configs {
+		if !config.Enable {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.RequestTimeout)*time.Second)
+		if _, err := broker.NewBudgetedClient(config).Zones(ctx); err != nil {
+			log.Error("broker", "refreshing zone catalogue of config %d: %v", config.Id, err)
+		}
+		cancel()
+	}
+}
+
 func Heartbeat() {
 	roots, err := dbhelper.GetRootAssets()
 	if err != nil {
@@ -421,8 +1205,14 @@ func Heartbeat() {
 		return
 	}
 
+	configs, err := dbhelper.GetConfigs(context.Background())
+	if err != nil {
+		log.Error("dbhelper", "getting configs: %v", err)
+		return
+	}
+
 	for _, root := range roots {
-		err := eliona.UpsertData(root.AssetID, map[string]any{"status": appStatus}, time.Now(), api.SUBTYPE_STATUS)
+		err := eliona.UpsertData(root.AssetID, map[string]any{"status": projectAppStatus(configs, root.ProjectID)}, time.Now(), api.SUBTYPE_STATUS)
 		if err != nil {
 			log.Error("eliona", "upserting data as heartbeat: %v", err)
 			return
@@ -437,9 +1227,12 @@ func ListenApi() {
 			utilshttp.NewCORSEnabledHandler(
 				apiserver.NewRouter(
 					apiserver.NewConfigurationAPIController(apiservices.NewConfigurationAPIService()),
+					apiserver.NewAlarmsAPIController(apiservices.NewAlarmsAPIService()),
+					apiserver.NewEmissionsAPIController(apiservices.NewEmissionsAPIService()),
 					apiserver.NewVersionAPIController(apiservices.NewVersionAPIService()),
+					apiserver.NewZonesAPIController(apiservices.NewZonesAPIService()),
 					apiserver.NewCustomizationAPIController(apiservices.NewCustomizationAPIService()),
 				))))
 	log.Fatal("main", "API server: %v", err)
-	changeAppStatus(statusFatal)
+	changeAllAppStatus(statusFatal)
 }
@@ -0,0 +1,181 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"electricity-maps/eliona"
+	"fmt"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	// emissionsStartHours is how far back the emissions are calculated for a newly configured meter.
+	emissionsStartHours = 24
+	// emissionsGracePeriod is how long an hour missing meter values or a zone reading is waited
+	// for, before it is skipped.
+	emissionsGracePeriod = 3 * time.Hour
+)
+
+// calculateEmissions writes the location-based Scope 2 emissions of the buildings whose meters
+// use the carbon intensity of the zone asset. Emissions assets missing for the buildings are
+// created on the fly.
+func calculateEmissions(ctx context.Context, config *appmodel.Configuration, zoneAsset appmodel.Asset) error {
+	meters, err := dbhelper.GetZoneBuildingMeters(ctx, zoneAsset.AssetID)
+	if err != nil {
+		return fmt.Errorf("getting building meters: %v", err)
+	}
+	for _, meter := range meters {
+		if meter.AssetID == 0 {
+			if meter.AssetID, err = createEmissionsAsset(ctx, config, meter); err != nil {
+				log.Error("app", "creating emissions asset of building %v: %v", meter.BuildingAssetID, err)
+				continue
+			}
+		}
+		if err := calculateBuildingEmissions(ctx, meter, zoneAsset); err != nil {
+			log.Error("app", "calculating emissions of building %v: %v", meter.BuildingAssetID, err)
+		}
+	}
+	return nil
+}
+
+// createEmissionsAsset creates the asset below the building the emissions of its meter are
+// written to.
+func createEmissionsAsset(ctx context.Context, config *appmodel.Configuration, meter appmodel.BuildingMeter) (int32, error) {
+	building, err := eliona.GetAsset(meter.BuildingAssetID)
+	if err != nil {
+		return 0, fmt.Errorf("getting building asset: %v", err)
+	}
+	emissions := &eliona.BuildingEmissions{
+		MeterID:      meter.ID,
+		BuildingName: building.GetName(),
+		BuildingGAI:  building.GlobalAssetIdentifier,
+	}
+	if err := eliona.CreateProjectAssets(*config, meter.ProjectID, []asset.AssetWithParentReferences{emissions}); err != nil {
+		return 0, err
+	}
+	created, err := dbhelper.GetBuildingMeter(ctx, meter.ID)
+	if err != nil {
+		return 0, fmt.Errorf("getting created asset: %v", err)
+	}
+	return created.AssetID, nil
+}
+
+// calculateBuildingEmissions writes the emissions of the energy consumed by the meter of a building,
+// for every complete hour not calculated yet. The consumption of an hour is the increase of the
+// meter's counter, multiplied by the carbon intensity the zone asset's configuration fetched for
+// that hour.
+func calculateBuildingEmissions(ctx context.Context, meter appmodel.BuildingMeter, zoneAsset appmodel.Asset) error {
+	end := time.Now().Truncate(time.Hour)
+	start := meter.EmissionsUntil
+	if start.IsZero() {
+		start = end.Add(-emissionsStartHours * time.Hour)
+	}
+	if !start.Before(end) {
+		return nil
+	}
+
+	toKWh, err := meterToKWh(meter)
+	if err != nil {
+		return err
+	}
+
+	zone := zoneAsset.LocationID
+	readings, err := dbhelper.GetZoneReadings(ctx, zoneAsset.ConfigurationID, zone, start, end)
+	if err != nil {
+		return fmt.Errorf("getting readings of zone %s: %v", zone, err)
+	}
+	intensities := make(map[int64]float64, len(readings))
+	for _, reading := range readings {
+		intensities[reading.Datetime.Unix()] = reading.CarbonIntensity
+	}
+
+	// The counter value at the start of the first hour may have been reported a while before.
+	samples, err := eliona.GetTrend(meter.MeterAssetID, meter.MeterAttribute, api.SUBTYPE_INPUT, start.Add(-time.Hour), end)
+	if err != nil {
+		return fmt.Errorf("getting meter values: %v", err)
+	}
+
+	until := start
+	for hour := start; hour.Before(end); hour = hour.Add(time.Hour) {
+		intensity, hasIntensity := intensities[hour.Unix()]
+		from, hasFrom := counterAt(samples, hour)
+		to, hasTo := counterAt(samples, hour.Add(time.Hour))
+		if !hasIntensity || !hasFrom || !hasTo {
+			if end.Sub(hour) <= emissionsGracePeriod {
+				break
+			}
+			log.Debug("app", "skipping emissions of building %v for %v: intensity %t, meter values %t/%t", meter.BuildingAssetID, hour, hasIntensity, hasFrom, hasTo)
+			until = hour.Add(time.Hour)
+			continue
+		}
+
+		consumption := (to - from) * toKWh
+		if consumption < 0 {
+			log.Warn("app", "meter %v of building %v was reset in the hour %v, skipping its emissions", meter.MeterAssetID, meter.BuildingAssetID, hour)
+			until = hour.Add(time.Hour)
+			continue
+		}
+		// kWh * gCO₂eq/kWh = gCO₂eq
+		emissions := consumption * intensity / 1000
+		if err := eliona.UpsertData(meter.AssetID, map[string]any{"scope2_emissions": emissions}, hour, api.SUBTYPE_INPUT); err != nil {
+			return fmt.Errorf("writing emissions: %v", err)
+		}
+		until = hour.Add(time.Hour)
+	}
+
+	if until.After(start) {
+		if err := dbhelper.SetBuildingMeterEmissionsUntil(ctx, meter.ID, until); err != nil {
+			return fmt.Errorf("storing calculated hours: %v", err)
+		}
+	}
+	return nil
+}
+
+// meterToKWh returns the factor converting the counter of the meter to kWh. The unit of the
+// meter's attribute is used unless the meter overrides it.
+func meterToKWh(meter appmodel.BuildingMeter) (float64, error) {
+	unit := meter.MeterUnit
+	if unit == "" {
+		var err error
+		if unit, err = eliona.GetAttributeUnit(meter.MeterAssetID, meter.MeterAttribute); err != nil {
+			return 0, fmt.Errorf("getting unit of meter: %v", err)
+		}
+	}
+	factor, ok := appmodel.EnergyUnitFactor(unit)
+	if !ok {
+		return 0, fmt.Errorf("unit %q of meter %v is not an energy unit", unit, meter.MeterAssetID)
+	}
+	return factor, nil
+}
+
+// counterAt returns the latest meter value reported at or before t
+func counterAt(samples []eliona.Sample, t time.Time) (float64, bool) {
+	value, found := 0.0, false
+	for _, sample := range samples {
+		if sample.Timestamp.After(t) {
+			break
+		}
+		value, found = sample.Value, true
+	}
+	return value, found
+}
@@ -15,6 +15,12 @@
 
 package appmodel
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Configuration struct {
 	Id              int64
 	ApiKey          string
@@ -24,16 +30,180 @@ type Configuration struct {
 	Active          bool
 	ProjectIDs      []string
 	UserId          string
+	BackfillHours   int32
+	ApiBaseUrl      string
+	ApiVersion      string
+	// RequestsPerSecond limits the request rate to the API, zero means unlimited.
+	RequestsPerSecond float64
+	// MonthlyRequestLimit is the request budget of the API plan, zero means unlimited.
+	MonthlyRequestLimit int64
+	// FailureThreshold is the percentage of failing assets from which the app status is Error
+	// instead of Degraded.
+	FailureThreshold int32
+	// Workers is the number of zones fetched concurrently.
+	Workers int32
+	// HistoryRetentionDays is how long zone readings are kept in the app's history, zero keeps them forever.
+	HistoryRetentionDays int32
+	// AutoProvision creates zone assets below the building assets of the configured projects.
+	AutoProvision bool
+	// BuildingAssetType is the asset type of the buildings zone assets are provisioned for.
+	BuildingAssetType string
+	// EmissionFactorType selects lifecycle or direct emission factors for the carbon intensity.
+	EmissionFactorType string
+	// MarginalSignal additionally collects the marginal carbon intensity.
+	MarginalSignal bool
+	// FlexibleLoadHours is the duration of the flexible load a green window is searched for, zero disables the search.
+	FlexibleLoadHours int32
+	// FlexibleLoadEarliestStart is the time of day (HH:MM) the flexible load may start at the earliest.
+	FlexibleLoadEarliestStart string
+	// FlexibleLoadDeadline is the time of day (HH:MM) the flexible load has to be finished by.
+	FlexibleLoadDeadline string
+	// TimeZone is the IANA time zone the times of day of the flexible load are in.
+	TimeZone string
 }
 
 type Asset struct {
-	ID         int64
-	ProjectID  string
-	LocationID string
-	AssetID    int32
+	ID int64
+	// ConfigurationID is the configuration whose API key the asset's zone is collected with.
+	ConfigurationID int64
+	ProjectID       string
+	LocationID      string
+	AssetID         int32
+	// LastDatetime is the datetime of the latest zone data written to the asset.
+	LastDatetime time.Time
+	// LastError of collecting data for the asset, empty if the last collection succeeded.
+	LastError string
+}
+
+// BuildingMeter is the energy meter of a building, whose consumption the Scope 2 emissions are
+// calculated for with the carbon intensity of the zone asset.
+type BuildingMeter struct {
+	ID              int64
+	BuildingAssetID int32
+	ZoneAssetID     int32
+	ProjectID       string
+	MeterAssetID    int32
+	MeterAttribute  string
+	// MeterUnit overrides the unit of the meter attribute, empty to use the attribute's unit.
+	MeterUnit string
+	// AssetID is the asset the emissions are written to, zero until it is created.
+	AssetID int32
+	// EmissionsUntil is the end of the last hour the emissions were calculated for.
+	EmissionsUntil time.Time
 }
 
+// BuildingZone is the zone a building of an auto-provisioned project is located in.
+type BuildingZone struct {
+	BuildingAssetID int32
+	// Latitude and Longitude are the coordinates the building was located at.
+	Latitude  float64
+	Longitude float64
+	// Zone is empty if there is no zone at the coordinates.
+	Zone     string
+	ZoneName string
+	// ZoneAssetID is the zone asset the building is linked to, zero until it is created.
+	ZoneAssetID int32
+}
+
+// energyUnits are the factors converting the units of energy meters to kWh
+var energyUnits = map[string]float64{
+	"wh":  0.001,
+	"kwh": 1,
+	"mwh": 1000,
+	"gwh": 1000000,
+}
+
+// EnergyUnitFactor returns the factor converting a counter in the unit to kWh.
+func EnergyUnitFactor(unit string) (float64, bool) {
+	factor, ok := energyUnits[strings.ToLower(strings.TrimSpace(unit))]
+	return factor, ok
+}
+
+// ParseTimeOfDay parses a time of day formatted as HH:MM to the duration since midnight
+func ParseTimeOfDay(timeOfDay string) (time.Duration, error) {
+	t, err := time.Parse("15:04", timeOfDay)
+	if err != nil {
+		return 0, fmt.Errorf("time of day %q is not formatted as HH:MM", timeOfDay)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// SchedulingPeriodLength returns the length of the period between the earliest start and the
+// deadline, the deadline being on the next day if it is not after the earliest start.
+func SchedulingPeriodLength(earliestStart, deadline time.Duration) time.Duration {
+	length := (deadline - earliestStart + 24*time.Hour) % (24 * time.Hour)
+	if length == 0 {
+		return 24 * time.Hour
+	}
+	return length
+}
+
+// RootAsset is the root asset of a project, shared by all configurations using the project.
 type RootAsset struct {
+	ID        int64
+	ProjectID string
+	AssetID   int32
+}
+
+type Flow struct {
+	ID            int64
+	ZoneAssetID   int32
+	NeighbourZone string
+	ProjectID     string
+	AssetID       int32
+}
+
+type ZoneReading struct {
+	// ConfigurationID is the configuration the reading was fetched with.
+	ConfigurationID       int64
+	Zone                  string
+	Datetime              time.Time
+	CarbonIntensity       float64
+	RenewablePercentage   float64
+	FossilFreePercentage  float64
+	PowerConsumptionTotal float64
+	PowerProductionTotal  float64
+	PowerImportTotal      float64
+	PowerExportTotal      float64
+	IsEstimated           bool
+	// Data is the full reading as JSON.
+	Data string
+}
+
+type Zone struct {
+	Code        string
+	Name        string
+	Access      []string
+	RefreshedAt time.Time
+	// APIKeyHash identifies the API key the zone was fetched with.
+	APIKeyHash string
+}
+
+// Comparisons of alarm rules
+const (
+	AlarmAbove = "above"
+	AlarmBelow = "below"
+)
+
+type AlarmRule struct {
 	ID      int64
 	AssetID int32
+	// Attribute of the zone asset the rule watches, e.g. carbon_intensity.
+	Attribute  string
+	Comparison string
+	Threshold  float64
+	// Hysteresis is how far the value has to be back from the threshold to clear the alarm.
+	Hysteresis float64
+	// MinDurationMinutes is how long the threshold has to be violated before the alarm fires.
+	MinDurationMinutes int32
+	Enable             bool
+	Message            string
+}
+
+type AlarmState struct {
+	RuleID int64
+	Active bool
+	// ViolatingSince is when the value went beyond the threshold, zero if it is not.
+	ViolatingSince time.Time
+	ChangedAt      time.Time
 }
@@ -0,0 +1,190 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	appmodel "electricity-maps/app/model"
+	"electricity-maps/broker"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata" // The time zones of the sites don't depend on the zone database of the image.
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Values of the grid_signal attribute
+const (
+	gridGreen   = 0
+	gridNeutral = 1
+	gridDirty   = 2
+)
+
+// gridSignalHorizon is the forecast period the current carbon intensity is compared with.
+const gridSignalHorizon = 24 * time.Hour
+
+var (
+	// forecasts holds the latest forecast of each zone per configuration, nil if it could not be
+	// fetched. The forecast is fetched once per hour of zone data, the scheduling signals are
+	// derived from it every cycle.
+	forecasts     = make(map[string]*broker.ZoneForecast)
+	forecastsLock sync.Mutex
+)
+
+func forecastKey(configID int64, zone string) string {
+	return fmt.Sprintf("%d/%s", configID, zone)
+}
+
+// cachedForecast returns the latest forecast of the zone and whether the zone was fetched before
+func cachedForecast(configID int64, zone string) (*broker.ZoneForecast, bool) {
+	forecastsLock.Lock()
+	defer forecastsLock.Unlock()
+	forecast, ok := forecasts[forecastKey(configID, zone)]
+	return forecast, ok
+}
+
+func cacheForecast(configID int64, zone string, forecast *broker.ZoneForecast) {
+	forecastsLock.Lock()
+	defer forecastsLock.Unlock()
+	forecasts[forecastKey(configID, zone)] = forecast
+}
+
+// schedulingToMap computes the carbon-aware scheduling attributes from the forecast of a zone: the
+// greenest window for the flexible load and whether the grid is currently green, neutral or dirty.
+func schedulingToMap(config *appmodel.Configuration, forecast broker.ZoneForecast, currentIntensity float64, now time.Time) map[string]interface{} {
+	attrMap := make(map[string]interface{})
+	if signal, ok := gridSignal(forecast, currentIntensity, now); ok {
+		attrMap["grid_signal"] = signal
+	}
+
+	if config.FlexibleLoadHours <= 0 {
+		return attrMap
+	}
+	duration := time.Duration(config.FlexibleLoadHours) * time.Hour
+	earliestStart, err := appmodel.ParseTimeOfDay(config.FlexibleLoadEarliestStart)
+	if err != nil {
+		log.Error("app", "parsing earliest start of the flexible load: %v", err)
+		return attrMap
+	}
+	deadline, err := appmodel.ParseTimeOfDay(config.FlexibleLoadDeadline)
+	if err != nil {
+		log.Error("app", "parsing deadline of the flexible load: %v", err)
+		return attrMap
+	}
+
+	location, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		log.Error("app", "loading time zone of the flexible load: %v", err)
+		return attrMap
+	}
+
+	periodStart, periodEnd := schedulingPeriod(earliestStart, deadline, duration, now.In(location))
+	start, average, ok := greenWindow(forecast, periodStart, periodEnd, duration)
+	if !ok {
+		log.Debug("app", "forecast of zone %s does not cover the flexible load period %v - %v", forecast.Zone, periodStart, periodEnd)
+		return attrMap
+	}
+	end := start.Add(duration)
+	active := 0
+	if !now.Before(start) && now.Before(end) {
+		active = 1
+	}
+	attrMap["green_window_start"] = start.Format(time.RFC3339)
+	attrMap["green_window_end"] = end.Format(time.RFC3339)
+	attrMap["green_window_average"] = average
+	attrMap["green_window_active"] = active
+	return attrMap
+}
+
+// schedulingPeriod returns the period the flexible load has to run in: from the earliest start, but
+// not before now, to the next deadline. The times of day are taken in the location of now. If the
+// load does not fit into what is left of it, the following period is returned.
+func schedulingPeriod(earliestStart, deadline, duration time.Duration, now time.Time) (time.Time, time.Time) {
+	length := appmodel.SchedulingPeriodLength(earliestStart, deadline)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := midnight.Add(deadline)
+	for !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	start := end.Add(-length)
+	if start.Before(now) {
+		start = now
+	}
+	if end.Sub(start) < duration {
+		end = end.AddDate(0, 0, 1)
+		start = end.Add(-length)
+	}
+	return start, end
+}
+
+// greenWindow finds the window of the given duration within the period having the lowest average
+// forecasted carbon intensity. Windows start on full hours, the current hour included.
+func greenWindow(forecast broker.ZoneForecast, periodStart, periodEnd time.Time, duration time.Duration) (time.Time, float64, bool) {
+	intensities := make(map[int64]float64, len(forecast.Forecast))
+	for _, point := range forecast.Forecast {
+		intensities[point.Datetime.Truncate(time.Hour).Unix()] = point.CarbonIntensity
+	}
+
+	var bestStart time.Time
+	bestAverage, found := 0.0, false
+	for start := periodStart.Truncate(time.Hour); !start.Add(duration).After(periodEnd); start = start.Add(time.Hour) {
+		sum, complete := 0.0, true
+		for hour := start; hour.Before(start.Add(duration)); hour = hour.Add(time.Hour) {
+			intensity, ok := intensities[hour.Unix()]
+			if !ok {
+				complete = false
+				break
+			}
+			sum += intensity
+		}
+		if !complete {
+			continue
+		}
+		average := sum / duration.Hours()
+		if !found || average < bestAverage {
+			bestStart, bestAverage, found = start, average, true
+		}
+	}
+	return bestStart, bestAverage, found
+}
+
+// gridSignal rates the current carbon intensity against the forecast of the next hours: green in
+// the lowest third of the values, dirty in the highest third and neutral in between.
+func gridSignal(forecast broker.ZoneForecast, currentIntensity float64, now time.Time) (int, bool) {
+	intensities := []float64{currentIntensity}
+	for _, point := range forecast.Forecast {
+		if point.Datetime.After(now) && !point.Datetime.After(now.Add(gridSignalHorizon)) {
+			intensities = append(intensities, point.CarbonIntensity)
+		}
+	}
+	if len(intensities) < 3 {
+		return 0, false
+	}
+	sort.Float64s(intensities)
+
+	lowerThird := intensities[len(intensities)/3]
+	upperThird := intensities[len(intensities)*2/3]
+	switch {
+	case currentIntensity <= lowerThird:
+		return gridGreen, true
+	case currentIntensity >= upperThird:
+		return gridDirty, true
+	default:
+		return gridNeutral, true
+	}
+}
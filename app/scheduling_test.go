@@ -0,0 +1,127 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"electricity-maps/broker"
+	"testing"
+	"time"
+)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, time.March, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestSchedulingPeriod(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		earliestStart time.Duration
+		deadline      time.Duration
+		duration      time.Duration
+		now           time.Time
+		wantStart     time.Time
+		wantEnd       time.Time
+	}{
+		{"before the period", 18 * time.Hour, 7 * time.Hour, 3 * time.Hour, at(1, 12, 0), at(1, 18, 0), at(2, 7, 0)},
+		{"within the period", 18 * time.Hour, 7 * time.Hour, 3 * time.Hour, at(1, 20, 0), at(1, 20, 0), at(2, 7, 0)},
+		{"load does not fit anymore", 18 * time.Hour, 7 * time.Hour, 3 * time.Hour, at(2, 5, 0), at(2, 18, 0), at(3, 7, 0)},
+		{"whole day", 0, 0, 2 * time.Hour, at(1, 10, 30), at(1, 10, 30), at(2, 0, 0)},
+		{"same day", 8 * time.Hour, 17 * time.Hour, time.Hour, at(1, 17, 0), at(2, 8, 0), at(2, 17, 0)},
+		{"site time zone", 18 * time.Hour, 7 * time.Hour, 3 * time.Hour, at(1, 12, 0).In(zurich), at(1, 17, 0), at(2, 6, 0)},
+		{"site time zone after midnight in UTC", 0, 6 * time.Hour, time.Hour, at(1, 23, 30).In(zurich), at(1, 23, 30), at(2, 5, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := schedulingPeriod(tt.earliestStart, tt.deadline, tt.duration, tt.now)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("got %v - %v, want %v - %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func forecastOf(start time.Time, intensities ...float64) broker.ZoneForecast {
+	forecast := broker.ZoneForecast{Zone: "CH"}
+	for i, intensity := range intensities {
+		forecast.Forecast = append(forecast.Forecast, broker.ForecastPoint{
+			CarbonIntensity: intensity,
+			Datetime:        start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return forecast
+}
+
+func TestGreenWindow(t *testing.T) {
+	forecast := forecastOf(at(1, 0, 0), 300, 100, 120, 400, 50, 60)
+	gap := forecastOf(at(1, 0, 0), 300, 100, 120, 400)
+	gap.Forecast = append(gap.Forecast, broker.ForecastPoint{CarbonIntensity: 60, Datetime: at(1, 5, 0)})
+
+	tests := []struct {
+		name        string
+		forecast    broker.ZoneForecast
+		periodStart time.Time
+		periodEnd   time.Time
+		wantStart   time.Time
+		wantAverage float64
+		wantOK      bool
+	}{
+		{"whole forecast", forecast, at(1, 0, 0), at(1, 6, 0), at(1, 4, 0), 55, true},
+		{"period ends earlier", forecast, at(1, 0, 0), at(1, 5, 0), at(1, 1, 0), 110, true},
+		{"current hour included", forecast, at(1, 3, 30), at(1, 6, 0), at(1, 4, 0), 55, true},
+		{"incomplete windows skipped", gap, at(1, 0, 0), at(1, 6, 0), at(1, 1, 0), 110, true},
+		{"not covered", forecast, at(2, 0, 0), at(2, 6, 0), time.Time{}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, average, ok := greenWindow(tt.forecast, tt.periodStart, tt.periodEnd, 2*time.Hour)
+			if ok != tt.wantOK || !start.Equal(tt.wantStart) || average != tt.wantAverage {
+				t.Errorf("got %v, %v, %v, want %v, %v, %v", start, average, ok, tt.wantStart, tt.wantAverage, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGridSignal(t *testing.T) {
+	now := at(1, 12, 0)
+	// Only the value at 13:00 is after now and within the horizon.
+	horizon := forecastOf(at(1, 12, 0), 200, 300)
+	horizon.Forecast = append(horizon.Forecast, broker.ForecastPoint{CarbonIntensity: 400, Datetime: at(2, 13, 0)})
+	tests := []struct {
+		name       string
+		forecast   broker.ZoneForecast
+		current    float64
+		wantSignal int
+		wantOK     bool
+	}{
+		{"green", forecastOf(at(1, 13, 0), 200, 300, 400), 100, gridGreen, true},
+		{"dirty", forecastOf(at(1, 13, 0), 200, 300, 400), 500, gridDirty, true},
+		{"neutral", forecastOf(at(1, 13, 0), 100, 200, 400, 500), 300, gridNeutral, true},
+		{"too few values", forecastOf(at(1, 13, 0), 200), 100, 0, false},
+		{"outside of the horizon", horizon, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal, ok := gridSignal(tt.forecast, tt.current, now)
+			if signal != tt.wantSignal || ok != tt.wantOK {
+				t.Errorf("got %v, %v, want %v, %v", signal, ok, tt.wantSignal, tt.wantOK)
+			}
+		})
+	}
+}
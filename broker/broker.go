@@ -16,23 +16,27 @@
 package broker
 
 import (
-	appmodel "electricity-maps/app/model"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/lithammer/fuzzysearch/fuzzy"
 )
 
 var ErrNotFound = errors.New("not found")
+var ErrAmbiguous = errors.New("ambiguous")
 
-// TestAuthentication tests if the provided API key is valid
-func TestAuthentication(config appmodel.Configuration) error {
-	_, err := getZones(config.ApiKey)
+// TestAuthentication tests if the provided API key is valid. The zones returned for the key
+// replace the zone catalogue.
+func (c *Client) TestAuthentication(ctx context.Context) error {
+	_, err := c.RefreshCatalogue(ctx)
 	return err
 }
 
@@ -43,95 +47,123 @@ type Zone struct {
 	Access   []string `json:"access"`
 }
 
+// Accessible reports whether the API key may use any endpoint for the zone
+func (z Zone) Accessible() bool {
+	return len(z.Access) > 0
+}
+
+// Allows reports whether the API key may use the endpoint, e.g. carbon-intensity/past-range, for the zone
+func (z Zone) Allows(endpoint string) bool {
+	return slices.Contains(z.Access, endpoint)
+}
+
 // zoneResponse represents the response from the zones endpoint
 type zoneResponse map[string]Zone
 
-func getZones(apiKey string) (zoneResponse, error) {
-	url := "https://api.electricitymap.org/v3/zones"
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) getZones(ctx context.Context) (zoneResponse, error) {
+	return fetchData[zoneResponse](ctx, c, c.endpoint("zones", nil))
+}
+
+// Locate finds a zone by its ID or name with fuzzy matching
+func (c *Client) Locate(ctx context.Context, name string) (Zone, error) {
+	zones, err := c.Zones(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return Zone{}, fmt.Errorf("getting zones: %w", err)
 	}
 
-	req.Header.Add("auth-token", apiKey)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
+	matches := RankZones(zones, name)
+	if len(matches) == 0 || matches[0].Distance >= maxLocateDistance {
+		return Zone{}, ErrNotFound
+	}
+	if matches[0].Distance > 0 && len(matches) > 1 && matches[1].Distance == matches[0].Distance {
+		// Guessing between equally good matches would silently map the asset to the wrong zone.
+		return Zone{}, ErrAmbiguous
 	}
-	defer resp.Body.Close()
+	return matches[0].Zone, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// Candidates returns the n zones best matching the name
+func (c *Client) Candidates(ctx context.Context, name string, n int) ([]ZoneMatch, error) {
+	zones, err := c.Zones(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
+		return nil, fmt.Errorf("getting zones: %w", err)
 	}
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		// Check for error response
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-			return nil, fmt.Errorf("API error: %s", errorResp.Error)
-		}
-		return nil, fmt.Errorf("unsuccessful response: %s: %v", resp.Status, string(body))
+	matches := RankZones(zones, name)
+	if len(matches) > n {
+		matches = matches[:n]
 	}
+	return matches, nil
+}
 
-	var zoneResponse zoneResponse
-	err = json.Unmarshal(body, &zoneResponse)
+// LocateByCoordinates finds the zone containing the given point
+func (c *Client) LocateByCoordinates(ctx context.Context, lat, lon float64) (Zone, error) {
+	query := url.Values{
+		"lat": {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"lon": {strconv.FormatFloat(lon, 'f', -1, 64)},
+	}
+	carbonData, err := fetchData[carbonIntensityResponse](ctx, c, c.endpoint("carbon-intensity/latest", c.carbonQuery(query)))
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+		return Zone{}, fmt.Errorf("getting carbon intensity for coordinates: %w", err)
+	}
+	if carbonData.Zone == "" {
+		return Zone{}, ErrNotFound
 	}
 
-	return zoneResponse, nil
-}
-
-// Locate finds a zone by its ID or name with fuzzy matching
-func Locate(config appmodel.Configuration, name string) (Zone, error) {
-	zones, err := getZones(config.ApiKey)
+	zones, err := c.Zones(ctx)
 	if err != nil {
 		return Zone{}, fmt.Errorf("getting zones: %w", err)
 	}
+	zone, ok := zones[carbonData.Zone]
+	if !ok {
+		zone = Zone{ZoneName: carbonData.Zone}
+	}
+	zone.Code = carbonData.Zone
+	return zone, nil
+}
+
+// maxLocateDistance is the largest fuzzy distance Locate accepts as a match
+const maxLocateDistance = 5
 
-	// Try to find the best match
-	var bestMatch Zone
-	bestDistance := 1000
+// ZoneMatch is a zone matching a search term. Distance is the fuzzy distance of the term to the
+// zone's code or name, whichever is closer; 0 is a perfect match.
+type ZoneMatch struct {
+	Zone     Zone
+	Distance int
+}
+
+// RankZones returns the zones matching the search term, best matches first
+func RankZones(zones map[string]Zone, name string) []ZoneMatch {
 	searchTerm := strings.ToLower(name)
 
+	var matches []ZoneMatch
 	for id, zone := range zones {
 		zone.Code = id
-		// Check ID
-		distance := fuzzy.RankMatchNormalizedFold(searchTerm, id)
-		if distance >= 0 && distance < bestDistance {
-			bestDistance = distance
-			bestMatch = zone
-			if bestDistance == 0 { // Perfect match
-				return bestMatch, nil
+		distance := -1
+		// The formatted "CODE - Name" is what the app writes back, so it has to match as well.
+		for _, target := range []string{id, zone.ZoneName, id + " - " + zone.ZoneName} {
+			if d := fuzzy.RankMatchNormalizedFold(searchTerm, target); d >= 0 && (distance < 0 || d < distance) {
+				distance = d
 			}
 		}
-
-		// Check ZoneName
-		distance = fuzzy.RankMatchNormalizedFold(searchTerm, zone.ZoneName)
-		if distance >= 0 && distance < bestDistance {
-			bestDistance = distance
-			bestMatch = zone
-			if bestDistance == 0 { // Perfect match
-				return bestMatch, nil
-			}
+		if distance < 0 {
+			continue
 		}
+		matches = append(matches, ZoneMatch{Zone: zone, Distance: distance})
 	}
 
-	if bestDistance < 5 { // Return if we have a reasonably good match
-		return bestMatch, nil
-	}
-
-	return Zone{}, ErrNotFound
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Zone.Code < matches[j].Zone.Code
+	})
+	return matches
 }
 
-// ListAvailableZones returns all available zones from the Electricity Maps API
-func ListAvailableZones(apiKey string) (map[string]Zone, error) {
-	zones, err := getZones(apiKey)
+// ListAvailableZones returns all available zones from the zone catalogue
+func (c *Client) ListAvailableZones(ctx context.Context) (map[string]Zone, error) {
+	zones, err := c.Zones(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get zones: %w", err)
 	}
@@ -155,6 +187,24 @@ type PowerBreakdown struct {
 	BatteryDischarge *float64 `json:"battery discharge"`
 }
 
+// Sources returns the values of all power sources keyed by their snake case name
+func (b PowerBreakdown) Sources() map[string]*float64 {
+	return map[string]*float64{
+		"nuclear":           b.Nuclear,
+		"geothermal":        b.Geothermal,
+		"biomass":           b.Biomass,
+		"coal":              b.Coal,
+		"wind":              b.Wind,
+		"solar":             b.Solar,
+		"hydro":             b.Hydro,
+		"gas":               b.Gas,
+		"oil":               b.Oil,
+		"unknown":           b.Unknown,
+		"hydro_discharge":   b.HydroDischarge,
+		"battery_discharge": b.BatteryDischarge,
+	}
+}
+
 // ZoneData represents the combined electricity data for a zone
 type ZoneData struct {
 	Zone                      string             `json:"zone"`
@@ -175,26 +225,32 @@ type ZoneData struct {
 	PowerProductionTotal      float64            `json:"powerProductionTotal"`
 	PowerImportTotal          float64            `json:"powerImportTotal"`
 	PowerExportTotal          float64            `json:"powerExportTotal"`
+	// MarginalCarbonIntensity is only set if the marginal signal is requested.
+	MarginalCarbonIntensity *float64 `json:"marginalCarbonIntensity,omitempty"`
 }
 
 // GetZoneData retrieves comprehensive electricity data for a specific zone
-func GetZoneData(zone string, apiKey string) (ZoneData, error) {
+func (c *Client) GetZoneData(ctx context.Context, zone string) (ZoneData, error) {
 	// First get carbon intensity data
-	carbonURL := fmt.Sprintf("https://api.electricitymap.org/v3/carbon-intensity/latest?zone=%s", zone)
-	carbonData, err := fetchData[carbonIntensityResponse](carbonURL, apiKey)
+	carbonURL := c.endpoint("carbon-intensity/latest", c.carbonQuery(url.Values{"zone": {zone}}))
+	carbonData, err := fetchData[carbonIntensityResponse](ctx, c, carbonURL)
 	if err != nil {
 		return ZoneData{}, fmt.Errorf("failed to get carbon intensity: %w", err)
 	}
 
 	// Then get power breakdown data
-	powerURL := fmt.Sprintf("https://api.electricitymap.org/v3/power-breakdown/latest?zone=%s", zone)
-	powerData, err := fetchData[powerBreakdownResponse](powerURL, apiKey)
+	powerURL := c.endpoint("power-breakdown/latest", url.Values{"zone": {zone}})
+	powerData, err := fetchData[powerBreakdownResponse](ctx, c, powerURL)
 	if err != nil {
 		return ZoneData{}, fmt.Errorf("failed to get power breakdown: %w", err)
 	}
 
-	// Merge the data into a single response
-	zoneData := ZoneData{
+	return mergeZoneData(carbonData, powerData), nil
+}
+
+// mergeZoneData combines carbon intensity and power breakdown data into a single response
+func mergeZoneData(carbonData carbonIntensityResponse, powerData powerBreakdownResponse) ZoneData {
+	return ZoneData{
 		Zone:                      carbonData.Zone,
 		CarbonIntensity:           carbonData.CarbonIntensity,
 		Datetime:                  carbonData.Datetime,
@@ -214,8 +270,134 @@ func GetZoneData(zone string, apiKey string) (ZoneData, error) {
 		PowerImportTotal:          powerData.PowerImportTotal,
 		PowerExportTotal:          powerData.PowerExportTotal,
 	}
+}
 
-	return zoneData, nil
+// maxPastRange is the longest period the past-range endpoints return in a single request
+const maxPastRange = 10 * 24 * time.Hour
+
+// historyResponse represents the responses of the history and past-range endpoints
+type historyResponse[T any] struct {
+	Zone    string `json:"zone"`
+	History []T    `json:"history"`
+	Data    []T    `json:"data"`
+}
+
+// GetZoneHistory retrieves hourly electricity data for a zone covering the given number of past hours.
+// Without past-range access for the zone, the history is limited to the last 24 hours.
+func (c *Client) GetZoneHistory(ctx context.Context, zone string, hours int) ([]ZoneData, error) {
+	if hours > 24 {
+		zones, err := c.Zones(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting zone catalogue: %w", err)
+		}
+		if !zones[zone].Allows("carbon-intensity/past-range") || !zones[zone].Allows("power-breakdown/past-range") {
+			log.Warn("broker", "The API plan has no past-range access for zone %s, loading the last 24 hours instead of %d.", zone, hours)
+			hours = 24
+		}
+	}
+
+	end := time.Now().UTC().Truncate(time.Hour)
+	start := end.Add(-time.Duration(hours) * time.Hour)
+
+	var carbonData []carbonIntensityResponse
+	var powerData []powerBreakdownResponse
+	if hours <= 24 {
+		// The history endpoints cover the last 24 hours and are available on all API plans.
+		carbonURL := c.endpoint("carbon-intensity/history", c.carbonQuery(url.Values{"zone": {zone}}))
+		carbonHistory, err := fetchData[historyResponse[carbonIntensityResponse]](ctx, c, carbonURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get carbon intensity history: %w", err)
+		}
+		powerURL := c.endpoint("power-breakdown/history", url.Values{"zone": {zone}})
+		powerHistory, err := fetchData[historyResponse[powerBreakdownResponse]](ctx, c, powerURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get power breakdown history: %w", err)
+		}
+		carbonData, powerData = carbonHistory.History, powerHistory.History
+	} else {
+		for from := start; from.Before(end); from = from.Add(maxPastRange) {
+			to := from.Add(maxPastRange)
+			if to.After(end) {
+				to = end
+			}
+			query := url.Values{"zone": {zone}, "start": {from.Format(time.RFC3339)}, "end": {to.Format(time.RFC3339)}}
+			carbonURL := c.endpoint("carbon-intensity/past-range", c.carbonQuery(query))
+			carbonRange, err := fetchData[historyResponse[carbonIntensityResponse]](ctx, c, carbonURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get carbon intensity past range: %w", err)
+			}
+			powerURL := c.endpoint("power-breakdown/past-range", query)
+			powerRange, err := fetchData[historyResponse[powerBreakdownResponse]](ctx, c, powerURL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get power breakdown past range: %w", err)
+			}
+			carbonData = append(carbonData, carbonRange.Data...)
+			powerData = append(powerData, powerRange.Data...)
+		}
+	}
+
+	powerByDatetime := make(map[time.Time]powerBreakdownResponse, len(powerData))
+	for _, power := range powerData {
+		powerByDatetime[power.Datetime] = power
+	}
+
+	history := make([]ZoneData, 0, len(carbonData))
+	for _, carbon := range carbonData {
+		if carbon.Datetime.Before(start) {
+			continue
+		}
+		history = append(history, mergeZoneData(carbon, powerByDatetime[carbon.Datetime]))
+	}
+	return history, nil
+}
+
+// ForecastPoint represents a single forecasted carbon intensity value
+type ForecastPoint struct {
+	CarbonIntensity float64   `json:"carbonIntensity"`
+	Datetime        time.Time `json:"datetime"`
+}
+
+// ZoneForecast represents the carbon intensity forecast for a zone
+type ZoneForecast struct {
+	Zone      string          `json:"zone"`
+	Forecast  []ForecastPoint `json:"forecast"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+// At returns the first forecasted value at or after the given time
+func (f ZoneForecast) At(t time.Time) (ForecastPoint, bool) {
+	for _, point := range f.Forecast {
+		if !point.Datetime.Before(t) {
+			return point, true
+		}
+	}
+	return ForecastPoint{}, false
+}
+
+// GetZoneForecast retrieves the carbon intensity forecast for a specific zone
+func (c *Client) GetZoneForecast(ctx context.Context, zone string) (ZoneForecast, error) {
+	forecastURL := c.endpoint("carbon-intensity/forecast", c.carbonQuery(url.Values{"zone": {zone}}))
+	forecast, err := fetchData[ZoneForecast](ctx, c, forecastURL)
+	if err != nil {
+		return ZoneForecast{}, fmt.Errorf("failed to get carbon intensity forecast: %w", err)
+	}
+	return forecast, nil
+}
+
+// GetMarginalCarbonIntensity retrieves the latest marginal carbon intensity for a specific zone
+func (c *Client) GetMarginalCarbonIntensity(ctx context.Context, zone string) (float64, error) {
+	marginalURL := c.endpoint("marginal-carbon-intensity/latest", c.carbonQuery(url.Values{"zone": {zone}}))
+	marginal, err := fetchData[marginalCarbonIntensityResponse](ctx, c, marginalURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get marginal carbon intensity: %w", err)
+	}
+	return marginal.MarginalCarbonIntensity, nil
+}
+
+type marginalCarbonIntensityResponse struct {
+	Zone                    string    `json:"zone"`
+	MarginalCarbonIntensity float64   `json:"marginalCarbonIntensity"`
+	Datetime                time.Time `json:"datetime"`
 }
 
 type carbonIntensityResponse struct {
@@ -247,44 +429,3 @@ type powerBreakdownResponse struct {
 	IsEstimated               bool               `json:"isEstimated"`
 	EstimationMethod          string             `json:"estimationMethod"`
 }
-
-func fetchData[T any](url string, apiKey string) (T, error) {
-	var empty T
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return empty, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Add("auth-token", apiKey)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return empty, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return empty, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		var errorResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-			return empty, fmt.Errorf("API error: %s", errorResp.Error)
-		}
-		return empty, fmt.Errorf("unsuccessful response: %s: %s", resp.Status, string(body))
-	}
-
-	var result T
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return empty, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
-}
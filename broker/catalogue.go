@@ -0,0 +1,146 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	"crypto/sha256"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// catalogueMaxAge is how long the zone catalogue is used before it is fetched again
+const catalogueMaxAge = 24 * time.Hour
+
+// cachedCatalogue holds the zones available to the API key of a configuration.
+type cachedCatalogue struct {
+	apiKey      string
+	zones       zoneResponse
+	refreshedAt time.Time
+}
+
+// catalogues caches the zone catalogue of each configuration in memory. The database keeps a copy,
+// so that restarts do not cost an API call.
+var catalogues = struct {
+	sync.RWMutex
+	byConfig map[int64]cachedCatalogue
+}{byConfig: make(map[int64]cachedCatalogue)}
+
+// Zones returns the zone catalogue. It is served from memory or the database and fetched from
+// the API only if it is missing, outdated or belongs to another API key.
+func (c *Client) Zones(ctx context.Context) (map[string]Zone, error) {
+	catalogues.RLock()
+	cached := catalogues.byConfig[c.configID]
+	catalogues.RUnlock()
+	zones := cached.zones
+	if zones != nil && cached.apiKey == c.apiKey && time.Since(cached.refreshedAt) < catalogueMaxAge {
+		return zones, nil
+	}
+
+	// Configurations not saved yet have no stored copy.
+	if zones == nil && c.configID != 0 {
+		stored, err := c.loadCatalogue(ctx)
+		if err != nil {
+			log.Warn("broker", "loading zone catalogue from database: %v", err)
+		} else if stored != nil {
+			return stored, nil
+		}
+	}
+
+	return c.RefreshCatalogue(ctx)
+}
+
+// apiKeyHash identifies the API key a stored catalogue was fetched with, without storing the key.
+func apiKeyHash(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// loadCatalogue fills the in-memory catalogue from the database, if the stored copy is recent enough
+// and was fetched with the current API key.
+func (c *Client) loadCatalogue(ctx context.Context) (zoneResponse, error) {
+	stored, err := dbhelper.GetZones(ctx, c.configID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 || time.Since(stored[0].RefreshedAt) >= catalogueMaxAge || stored[0].APIKeyHash != apiKeyHash(c.apiKey) {
+		return nil, nil
+	}
+
+	zones := make(zoneResponse, len(stored))
+	for _, zone := range stored {
+		zones[zone.Code] = Zone{
+			Code:     zone.Code,
+			ZoneName: zone.Name,
+			Access:   zone.Access,
+		}
+	}
+
+	catalogues.Lock()
+	defer catalogues.Unlock()
+	catalogues.byConfig[c.configID] = cachedCatalogue{
+		apiKey:      c.apiKey,
+		zones:       zones,
+		refreshedAt: stored[0].RefreshedAt,
+	}
+	return zones, nil
+}
+
+// RefreshCatalogue fetches the zones from the API and replaces the cached catalogue
+func (c *Client) RefreshCatalogue(ctx context.Context) (map[string]Zone, error) {
+	zones, err := c.getZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting zones: %w", err)
+	}
+
+	now := time.Now()
+	keyHash := apiKeyHash(c.apiKey)
+	stored := make([]appmodel.Zone, 0, len(zones))
+	for code, zone := range zones {
+		zone.Code = code
+		zones[code] = zone
+		stored = append(stored, appmodel.Zone{
+			Code:        code,
+			Name:        zone.ZoneName,
+			Access:      zone.Access,
+			RefreshedAt: now,
+			APIKeyHash:  keyHash,
+		})
+	}
+	if c.configID == 0 {
+		// Configurations not saved yet, e.g. while testing the authentication, are not cached.
+		return zones, nil
+	}
+	if err := dbhelper.ReplaceZones(ctx, c.configID, stored); err != nil {
+		// The catalogue is still usable from memory.
+		log.Error("broker", "storing zone catalogue: %v", err)
+	}
+
+	catalogues.Lock()
+	defer catalogues.Unlock()
+	catalogues.byConfig[c.configID] = cachedCatalogue{
+		apiKey:      c.apiKey,
+		zones:       zones,
+		refreshedAt: now,
+	}
+	return zones, nil
+}
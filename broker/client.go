@@ -0,0 +1,311 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package broker
+
+import (
+	"context"
+	appmodel "electricity-maps/app/model"
+	dbhelper "electricity-maps/db/helper"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// defaultRequestTimeout is used for configurations without a request timeout
+const defaultRequestTimeout = 120 * time.Second
+
+const (
+	maxRetries     = 4
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+var ErrBudgetExhausted = errors.New("monthly request budget exhausted")
+
+// RequestCounter counts a request against the monthly budget unless the limit is reached. It
+// returns the number of requests made in the current month and whether this one was counted.
+type RequestCounter func(ctx context.Context, limit int64) (requests int64, counted bool, err error)
+
+// Client accesses the Electricity Maps API with the settings of a configuration
+type Client struct {
+	configID       int64
+	httpClient     *http.Client
+	baseURL        string
+	apiVersion     string
+	apiKey         string
+	limiter        *rateLimiter
+	monthlyLimit   int64
+	requestCounter RequestCounter
+	// emissionFactorType is requested for all carbon intensities, empty for the API's default.
+	emissionFactorType string
+}
+
+// ClientOption for how the client is set up.
+type ClientOption func(*Client)
+
+// WithRequestCounter enforces the monthly request budget of the configuration using the counter
+func WithRequestCounter(counter RequestCounter) ClientOption {
+	return func(c *Client) {
+		c.requestCounter = counter
+	}
+}
+
+// NewClient creates a client for the API described by the configuration
+func NewClient(config appmodel.Configuration, opts ...ClientOption) *Client {
+	timeout := time.Duration(config.RequestTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	client := &Client{
+		configID:           config.Id,
+		httpClient:         &http.Client{Timeout: timeout},
+		baseURL:            strings.TrimSuffix(config.ApiBaseUrl, "/"),
+		apiVersion:         config.ApiVersion,
+		apiKey:             config.ApiKey,
+		limiter:            limiterFor(config.ApiKey, config.RequestsPerSecond),
+		monthlyLimit:       config.MonthlyRequestLimit,
+		emissionFactorType: config.EmissionFactorType,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// NewBudgetedClient creates a client counting its requests against the monthly budget of the
+// configuration. Configurations not saved yet have no budget to count against.
+func NewBudgetedClient(config appmodel.Configuration) *Client {
+	if config.Id == 0 {
+		return NewClient(config)
+	}
+	return NewClient(config, WithRequestCounter(func(ctx context.Context, limit int64) (int64, bool, error) {
+		return dbhelper.CountAPIRequest(ctx, config.Id, limit)
+	}))
+}
+
+// endpoint builds the URL of an API endpoint using the configured base URL and API version
+func (c *Client) endpoint(path string, query url.Values) string {
+	endpointURL := fmt.Sprintf("%s/%s/%s", c.baseURL, c.apiVersion, path)
+	if len(query) > 0 {
+		endpointURL += "?" + query.Encode()
+	}
+	return endpointURL
+}
+
+// carbonQuery adds the configured emission factor type to the query of a carbon intensity endpoint
+func (c *Client) carbonQuery(query url.Values) url.Values {
+	if c.emissionFactorType == "" {
+		return query
+	}
+	carbonQuery := url.Values{"emissionFactorType": {c.emissionFactorType}}
+	for key, values := range query {
+		carbonQuery[key] = values
+	}
+	return carbonQuery
+}
+
+// spendBudget counts a request against the monthly budget, if there is one
+func (c *Client) spendBudget(ctx context.Context) error {
+	if c.monthlyLimit <= 0 || c.requestCounter == nil {
+		return nil
+	}
+	// Refused requests are not counted, so they don't eat into the month's usage.
+	count, counted, err := c.requestCounter(ctx, c.monthlyLimit)
+	if err != nil {
+		return fmt.Errorf("counting request: %w", err)
+	}
+	if !counted {
+		return fmt.Errorf("%w: %d of %d requests used", ErrBudgetExhausted, count, c.monthlyLimit)
+	}
+	return nil
+}
+
+// fetchData requests the URL and decodes the response. Throttled requests, server errors and
+// network failures are retried with exponential backoff, honouring the Retry-After header.
+func fetchData[T any](ctx context.Context, c *Client, url string) (T, error) {
+	var empty T
+
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return empty, err
+		}
+		if err := c.spendBudget(ctx); err != nil {
+			return empty, err
+		}
+
+		var retryAfter time.Duration
+		var retryable bool
+		var err error
+		body, retryAfter, retryable, err = c.do(ctx, url)
+		if err == nil {
+			break
+		}
+		if !retryable || attempt >= maxRetries || ctx.Err() != nil {
+			return empty, err
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = backoff(attempt)
+		}
+		// A long Retry-After, e.g. for an exhausted quota, would stall the collection. The request
+		// fails instead and is made again in the next cycle.
+		if delay > maxBackoff {
+			return empty, fmt.Errorf("%w (retry after %v)", err, delay.Round(time.Second))
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return empty, fmt.Errorf("%w (retry after %v exceeds deadline)", err, delay.Round(time.Second))
+		}
+		log.Debug("broker", "retrying request in %v (attempt %d of %d): %v", delay, attempt+1, maxRetries, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return empty, ctx.Err()
+		}
+	}
+
+	var result T
+	err := json.Unmarshal(body, &result)
+	if err != nil {
+		return empty, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
+
+// do makes a single request and reports whether a failure is worth retrying
+func (c *Client) do(ctx context.Context, url string) (body []byte, retryAfter time.Duration, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Add("auth-token", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, true, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, true, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		retryable = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+		var errorResp struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
+			return nil, retryAfter, retryable, fmt.Errorf("API error: %s", errorResp.Error)
+		}
+		return nil, retryAfter, retryable, fmt.Errorf("unsuccessful response: %s: %s", resp.Status, string(body))
+	}
+
+	return body, 0, false, nil
+}
+
+// parseRetryAfter parses the Retry-After header given either in seconds or as HTTP date
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// backoff returns the exponential backoff with full jitter for the given attempt
+func backoff(attempt int) time.Duration {
+	delay := initialBackoff << attempt
+	if delay > maxBackoff || delay <= 0 {
+		delay = maxBackoff
+	}
+	return rand.N(delay) + 1
+}
+
+// rateLimiter spaces requests sharing an API key evenly
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+var (
+	limiters     = make(map[string]*rateLimiter)
+	limitersLock sync.Mutex
+)
+
+// limiterFor returns the limiter shared by all clients using the API key
+func limiterFor(apiKey string, requestsPerSecond float64) *rateLimiter {
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+
+	limiter, ok := limiters[apiKey]
+	if !ok {
+		limiter = &rateLimiter{}
+		limiters[apiKey] = limiter
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.interval = 0
+	if requestsPerSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return limiter
+}
+
+// wait blocks until the next request is allowed
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
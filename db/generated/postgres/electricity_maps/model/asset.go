@@ -7,9 +7,16 @@
 
 package model
 
+import (
+	"time"
+)
+
 type Asset struct {
-	ID         int64 `sql:"primary_key"`
-	ProjectID  string
-	LocationID string
-	AssetID    int32
+	ID              int64 `sql:"primary_key"`
+	ProjectID       string
+	LocationID      string
+	AssetID         int32
+	LastDatetime    *time.Time
+	LastError       *string
+	ConfigurationID int32
 }
@@ -12,12 +12,28 @@ import (
 )
 
 type Configuration struct {
-	ID              int32 `sql:"primary_key"`
-	APIKey          string
-	RefreshInterval int32
-	RequestTimeout  int32
-	Active          bool
-	Enable          bool
-	ProjectIds      pq.StringArray
-	UserID          string
+	ID                        int32 `sql:"primary_key"`
+	APIKey                    string
+	RefreshInterval           int32
+	RequestTimeout            int32
+	Active                    bool
+	Enable                    bool
+	ProjectIds                pq.StringArray
+	UserID                    string
+	BackfillHours             int32
+	APIBaseURL                string
+	APIVersion                string
+	RequestsPerSecond         float64
+	MonthlyRequestLimit       int64
+	FailureThreshold          int32
+	Workers                   int32
+	HistoryRetentionDays      int32
+	AutoProvision             bool
+	BuildingAssetType         string
+	EmissionFactorType        string
+	MarginalSignal            bool
+	FlexibleLoadHours         int32
+	FlexibleLoadEarliestStart string
+	FlexibleLoadDeadline      string
+	TimeZone                  string
 }
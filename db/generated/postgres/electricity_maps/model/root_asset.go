@@ -8,9 +8,8 @@
 package model
 
 type RootAsset struct {
-	ID              int32 `sql:"primary_key"`
-	ConfigurationID int32
-	ProjectID       string
-	Gai             string
-	AssetID         int32
+	ID        int32 `sql:"primary_key"`
+	ProjectID string
+	Gai       string
+	AssetID   int32
 }
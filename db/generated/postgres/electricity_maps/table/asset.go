@@ -17,10 +17,13 @@ type assetTable struct {
 	postgres.Table
 
 	// Columns
-	ID         postgres.ColumnInteger
-	ProjectID  postgres.ColumnString
-	LocationID postgres.ColumnString
-	AssetID    postgres.ColumnInteger
+	ID              postgres.ColumnInteger
+	ProjectID       postgres.ColumnString
+	LocationID      postgres.ColumnString
+	AssetID         postgres.ColumnInteger
+	LastDatetime    postgres.ColumnTimestampz
+	LastError       postgres.ColumnString
+	ConfigurationID postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -62,23 +65,29 @@ func newAssetTable(schemaName, tableName, alias string) *AssetTable {
 
 func newAssetTableImpl(schemaName, tableName, alias string) assetTable {
 	var (
-		IDColumn         = postgres.IntegerColumn("id")
-		ProjectIDColumn  = postgres.StringColumn("project_id")
-		LocationIDColumn = postgres.StringColumn("location_id")
-		AssetIDColumn    = postgres.IntegerColumn("asset_id")
-		allColumns       = postgres.ColumnList{IDColumn, ProjectIDColumn, LocationIDColumn, AssetIDColumn}
-		mutableColumns   = postgres.ColumnList{ProjectIDColumn, LocationIDColumn, AssetIDColumn}
-		defaultColumns   = postgres.ColumnList{IDColumn}
+		IDColumn              = postgres.IntegerColumn("id")
+		ProjectIDColumn       = postgres.StringColumn("project_id")
+		LocationIDColumn      = postgres.StringColumn("location_id")
+		AssetIDColumn         = postgres.IntegerColumn("asset_id")
+		LastDatetimeColumn    = postgres.TimestampzColumn("last_datetime")
+		LastErrorColumn       = postgres.StringColumn("last_error")
+		ConfigurationIDColumn = postgres.IntegerColumn("configuration_id")
+		allColumns            = postgres.ColumnList{IDColumn, ProjectIDColumn, LocationIDColumn, AssetIDColumn, LastDatetimeColumn, LastErrorColumn, ConfigurationIDColumn}
+		mutableColumns        = postgres.ColumnList{ProjectIDColumn, LocationIDColumn, AssetIDColumn, LastDatetimeColumn, LastErrorColumn, ConfigurationIDColumn}
+		defaultColumns        = postgres.ColumnList{IDColumn}
 	)
 
 	return assetTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:         IDColumn,
-		ProjectID:  ProjectIDColumn,
-		LocationID: LocationIDColumn,
-		AssetID:    AssetIDColumn,
+		ID:              IDColumn,
+		ProjectID:       ProjectIDColumn,
+		LocationID:      LocationIDColumn,
+		AssetID:         AssetIDColumn,
+		LastDatetime:    LastDatetimeColumn,
+		LastError:       LastErrorColumn,
+		ConfigurationID: ConfigurationIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
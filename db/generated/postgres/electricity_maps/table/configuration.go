@@ -17,14 +17,30 @@ type configurationTable struct {
 	postgres.Table
 
 	// Columns
-	ID              postgres.ColumnInteger
-	APIKey          postgres.ColumnString
-	RefreshInterval postgres.ColumnInteger
-	RequestTimeout  postgres.ColumnInteger
-	Active          postgres.ColumnBool
-	Enable          postgres.ColumnBool
-	ProjectIds      postgres.ColumnString
-	UserID          postgres.ColumnString
+	ID                        postgres.ColumnInteger
+	APIKey                    postgres.ColumnString
+	RefreshInterval           postgres.ColumnInteger
+	RequestTimeout            postgres.ColumnInteger
+	Active                    postgres.ColumnBool
+	Enable                    postgres.ColumnBool
+	ProjectIds                postgres.ColumnString
+	UserID                    postgres.ColumnString
+	BackfillHours             postgres.ColumnInteger
+	APIBaseURL                postgres.ColumnString
+	APIVersion                postgres.ColumnString
+	RequestsPerSecond         postgres.ColumnFloat
+	MonthlyRequestLimit       postgres.ColumnInteger
+	FailureThreshold          postgres.ColumnInteger
+	Workers                   postgres.ColumnInteger
+	HistoryRetentionDays      postgres.ColumnInteger
+	AutoProvision             postgres.ColumnBool
+	BuildingAssetType         postgres.ColumnString
+	EmissionFactorType        postgres.ColumnString
+	MarginalSignal            postgres.ColumnBool
+	FlexibleLoadHours         postgres.ColumnInteger
+	FlexibleLoadEarliestStart postgres.ColumnString
+	FlexibleLoadDeadline      postgres.ColumnString
+	TimeZone                  postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,31 +82,63 @@ func newConfigurationTable(schemaName, tableName, alias string) *ConfigurationTa
 
 func newConfigurationTableImpl(schemaName, tableName, alias string) configurationTable {
 	var (
-		IDColumn              = postgres.IntegerColumn("id")
-		APIKeyColumn          = postgres.StringColumn("api_key")
-		RefreshIntervalColumn = postgres.IntegerColumn("refresh_interval")
-		RequestTimeoutColumn  = postgres.IntegerColumn("request_timeout")
-		ActiveColumn          = postgres.BoolColumn("active")
-		EnableColumn          = postgres.BoolColumn("enable")
-		ProjectIdsColumn      = postgres.StringColumn("project_ids")
-		UserIDColumn          = postgres.StringColumn("user_id")
-		allColumns            = postgres.ColumnList{IDColumn, APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn}
-		mutableColumns        = postgres.ColumnList{APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn}
-		defaultColumns        = postgres.ColumnList{IDColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn}
+		IDColumn                        = postgres.IntegerColumn("id")
+		APIKeyColumn                    = postgres.StringColumn("api_key")
+		RefreshIntervalColumn           = postgres.IntegerColumn("refresh_interval")
+		RequestTimeoutColumn            = postgres.IntegerColumn("request_timeout")
+		ActiveColumn                    = postgres.BoolColumn("active")
+		EnableColumn                    = postgres.BoolColumn("enable")
+		ProjectIdsColumn                = postgres.StringColumn("project_ids")
+		UserIDColumn                    = postgres.StringColumn("user_id")
+		BackfillHoursColumn             = postgres.IntegerColumn("backfill_hours")
+		APIBaseURLColumn                = postgres.StringColumn("api_base_url")
+		APIVersionColumn                = postgres.StringColumn("api_version")
+		RequestsPerSecondColumn         = postgres.FloatColumn("requests_per_second")
+		MonthlyRequestLimitColumn       = postgres.IntegerColumn("monthly_request_limit")
+		FailureThresholdColumn          = postgres.IntegerColumn("failure_threshold")
+		WorkersColumn                   = postgres.IntegerColumn("workers")
+		HistoryRetentionDaysColumn      = postgres.IntegerColumn("history_retention_days")
+		AutoProvisionColumn             = postgres.BoolColumn("auto_provision")
+		BuildingAssetTypeColumn         = postgres.StringColumn("building_asset_type")
+		EmissionFactorTypeColumn        = postgres.StringColumn("emission_factor_type")
+		MarginalSignalColumn            = postgres.BoolColumn("marginal_signal")
+		FlexibleLoadHoursColumn         = postgres.IntegerColumn("flexible_load_hours")
+		FlexibleLoadEarliestStartColumn = postgres.StringColumn("flexible_load_earliest_start")
+		FlexibleLoadDeadlineColumn      = postgres.StringColumn("flexible_load_deadline")
+		TimeZoneColumn                  = postgres.StringColumn("time_zone")
+		allColumns                      = postgres.ColumnList{IDColumn, APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn, BackfillHoursColumn, APIBaseURLColumn, APIVersionColumn, RequestsPerSecondColumn, MonthlyRequestLimitColumn, FailureThresholdColumn, WorkersColumn, HistoryRetentionDaysColumn, AutoProvisionColumn, BuildingAssetTypeColumn, EmissionFactorTypeColumn, MarginalSignalColumn, FlexibleLoadHoursColumn, FlexibleLoadEarliestStartColumn, FlexibleLoadDeadlineColumn, TimeZoneColumn}
+		mutableColumns                  = postgres.ColumnList{APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn, BackfillHoursColumn, APIBaseURLColumn, APIVersionColumn, RequestsPerSecondColumn, MonthlyRequestLimitColumn, FailureThresholdColumn, WorkersColumn, HistoryRetentionDaysColumn, AutoProvisionColumn, BuildingAssetTypeColumn, EmissionFactorTypeColumn, MarginalSignalColumn, FlexibleLoadHoursColumn, FlexibleLoadEarliestStartColumn, FlexibleLoadDeadlineColumn, TimeZoneColumn}
+		defaultColumns                  = postgres.ColumnList{IDColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, BackfillHoursColumn, APIBaseURLColumn, APIVersionColumn, RequestsPerSecondColumn, MonthlyRequestLimitColumn, FailureThresholdColumn, WorkersColumn, HistoryRetentionDaysColumn, AutoProvisionColumn, BuildingAssetTypeColumn, EmissionFactorTypeColumn, MarginalSignalColumn, FlexibleLoadHoursColumn, FlexibleLoadEarliestStartColumn, FlexibleLoadDeadlineColumn, TimeZoneColumn}
 	)
 
 	return configurationTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:              IDColumn,
-		APIKey:          APIKeyColumn,
-		RefreshInterval: RefreshIntervalColumn,
-		RequestTimeout:  RequestTimeoutColumn,
-		Active:          ActiveColumn,
-		Enable:          EnableColumn,
-		ProjectIds:      ProjectIdsColumn,
-		UserID:          UserIDColumn,
+		ID:                        IDColumn,
+		APIKey:                    APIKeyColumn,
+		RefreshInterval:           RefreshIntervalColumn,
+		RequestTimeout:            RequestTimeoutColumn,
+		Active:                    ActiveColumn,
+		Enable:                    EnableColumn,
+		ProjectIds:                ProjectIdsColumn,
+		UserID:                    UserIDColumn,
+		BackfillHours:             BackfillHoursColumn,
+		APIBaseURL:                APIBaseURLColumn,
+		APIVersion:                APIVersionColumn,
+		RequestsPerSecond:         RequestsPerSecondColumn,
+		MonthlyRequestLimit:       MonthlyRequestLimitColumn,
+		FailureThreshold:          FailureThresholdColumn,
+		Workers:                   WorkersColumn,
+		HistoryRetentionDays:      HistoryRetentionDaysColumn,
+		AutoProvision:             AutoProvisionColumn,
+		BuildingAssetType:         BuildingAssetTypeColumn,
+		EmissionFactorType:        EmissionFactorTypeColumn,
+		MarginalSignal:            MarginalSignalColumn,
+		FlexibleLoadHours:         FlexibleLoadHoursColumn,
+		FlexibleLoadEarliestStart: FlexibleLoadEarliestStartColumn,
+		FlexibleLoadDeadline:      FlexibleLoadDeadlineColumn,
+		TimeZone:                  TimeZoneColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,11 +17,10 @@ type rootAssetTable struct {
 	postgres.Table
 
 	// Columns
-	ID              postgres.ColumnInteger
-	ConfigurationID postgres.ColumnInteger
-	ProjectID       postgres.ColumnString
-	Gai             postgres.ColumnString
-	AssetID         postgres.ColumnInteger
+	ID        postgres.ColumnInteger
+	ProjectID postgres.ColumnString
+	Gai       postgres.ColumnString
+	AssetID   postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,25 +62,23 @@ func newRootAssetTable(schemaName, tableName, alias string) *RootAssetTable {
 
 func newRootAssetTableImpl(schemaName, tableName, alias string) rootAssetTable {
 	var (
-		IDColumn              = postgres.IntegerColumn("id")
-		ConfigurationIDColumn = postgres.IntegerColumn("configuration_id")
-		ProjectIDColumn       = postgres.StringColumn("project_id")
-		GaiColumn             = postgres.StringColumn("gai")
-		AssetIDColumn         = postgres.IntegerColumn("asset_id")
-		allColumns            = postgres.ColumnList{IDColumn, ConfigurationIDColumn, ProjectIDColumn, GaiColumn, AssetIDColumn}
-		mutableColumns        = postgres.ColumnList{ConfigurationIDColumn, ProjectIDColumn, GaiColumn, AssetIDColumn}
-		defaultColumns        = postgres.ColumnList{}
+		IDColumn        = postgres.IntegerColumn("id")
+		ProjectIDColumn = postgres.StringColumn("project_id")
+		GaiColumn       = postgres.StringColumn("gai")
+		AssetIDColumn   = postgres.IntegerColumn("asset_id")
+		allColumns      = postgres.ColumnList{IDColumn, ProjectIDColumn, GaiColumn, AssetIDColumn}
+		mutableColumns  = postgres.ColumnList{ProjectIDColumn, GaiColumn, AssetIDColumn}
+		defaultColumns  = postgres.ColumnList{}
 	)
 
 	return rootAssetTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:              IDColumn,
-		ConfigurationID: ConfigurationIDColumn,
-		ProjectID:       ProjectIDColumn,
-		Gai:             GaiColumn,
-		AssetID:         AssetIDColumn,
+		ID:        IDColumn,
+		ProjectID: ProjectIDColumn,
+		Gai:       GaiColumn,
+		AssetID:   AssetIDColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -10,7 +10,16 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	APIUsage = APIUsage.FromSchema(schema)
+	AlarmRule = AlarmRule.FromSchema(schema)
+	AlarmState = AlarmState.FromSchema(schema)
 	Asset = Asset.FromSchema(schema)
+	BuildingMeter = BuildingMeter.FromSchema(schema)
+	BuildingZone = BuildingZone.FromSchema(schema)
 	Configuration = Configuration.FromSchema(schema)
+	Flow = Flow.FromSchema(schema)
 	RootAsset = RootAsset.FromSchema(schema)
+	SchemaVersion = SchemaVersion.FromSchema(schema)
+	Zone = Zone.FromSchema(schema)
+	ZoneReading = ZoneReading.FromSchema(schema)
 }
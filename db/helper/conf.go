@@ -21,6 +21,7 @@ import (
 	appmodel "electricity-maps/app/model"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
@@ -69,6 +70,11 @@ var ErrBadRequest = errors.New("bad request")
 var ErrNotFound = errors.New("not found")
 
 func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.Configuration, error) {
+	apiKey, err := encryptSecret(config.ApiKey)
+	if err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("encrypting API key: %w", err)
+	}
+
 	commonColumns := ColumnList{
 		Configuration.APIKey,
 		Configuration.RefreshInterval,
@@ -76,57 +82,78 @@ func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.
 		Configuration.Active,
 		Configuration.Enable,
 		Configuration.ProjectIds,
-		Configuration.UserID,
+		Configuration.BackfillHours,
+		Configuration.APIBaseURL,
+		Configuration.APIVersion,
+		Configuration.RequestsPerSecond,
+		Configuration.MonthlyRequestLimit,
+		Configuration.FailureThreshold,
+		Configuration.Workers,
+		Configuration.HistoryRetentionDays,
+		Configuration.AutoProvision,
+		Configuration.BuildingAssetType,
+		Configuration.EmissionFactorType,
+		Configuration.MarginalSignal,
+		Configuration.FlexibleLoadHours,
+		Configuration.FlexibleLoadEarliestStart,
+		Configuration.FlexibleLoadDeadline,
+		Configuration.TimeZone,
 	}
 
 	commonValues := []interface{}{
-		config.ApiKey,
+		apiKey,
 		config.RefreshInterval,
 		config.RequestTimeout,
 		config.Active,
 		config.Enable,
 		pq.StringArray(config.ProjectIDs),
-		frontend.GetEnvironment(ctx).UserId,
+		config.BackfillHours,
+		config.ApiBaseUrl,
+		config.ApiVersion,
+		config.RequestsPerSecond,
+		config.MonthlyRequestLimit,
+		config.FailureThreshold,
+		config.Workers,
+		config.HistoryRetentionDays,
+		config.AutoProvision,
+		config.BuildingAssetType,
+		config.EmissionFactorType,
+		config.MarginalSignal,
+		config.FlexibleLoadHours,
+		config.FlexibleLoadEarliestStart,
+		config.FlexibleLoadDeadline,
+		config.TimeZone,
 	}
 
-	stmt := Configuration.INSERT()
-
+	var stmt Statement
 	if config.Id != 0 {
-		// If ID is provided, include it in the INSERT
-		columns := append(commonColumns, Configuration.ID)
-		values := append(commonValues, config.Id)
-
-		stmt = Configuration.INSERT(columns).VALUES(values[0], values[1:]...).ON_CONFLICT(
-			Configuration.ID,
-		).DO_UPDATE(
-			SET(
-				Configuration.APIKey.SET(Configuration.EXCLUDED.APIKey),
-				Configuration.RefreshInterval.SET(Configuration.EXCLUDED.RefreshInterval),
-				Configuration.RequestTimeout.SET(Configuration.EXCLUDED.RequestTimeout),
-				Configuration.Active.SET(Configuration.EXCLUDED.Active),
-				Configuration.Enable.SET(Configuration.EXCLUDED.Enable),
-				Configuration.ProjectIds.SET(Configuration.EXCLUDED.ProjectIds),
-			),
-		)
+		// The configuration keeps the user who created it.
+		stmt = Configuration.UPDATE(commonColumns).SET(commonValues[0], commonValues[1:]...).WHERE(
+			Configuration.ID.EQ(Int(config.Id)),
+		).RETURNING(Configuration.AllColumns)
 	} else {
 		// If ID is 0, omit it to allow auto-increment
-		stmt = Configuration.INSERT(commonColumns).VALUES(commonValues[0], commonValues[1:]...)
+		columns := append(commonColumns, Configuration.UserID)
+		values := append(commonValues, frontend.GetEnvironment(ctx).UserId)
+		stmt = Configuration.INSERT(columns).VALUES(values[0], values[1:]...).RETURNING(Configuration.AllColumns)
 	}
 
-	stmt = stmt.RETURNING(Configuration.AllColumns)
-
 	var updatedConfig model.Configuration
-	if err := stmt.QueryContext(ctx, GetDB().db, &updatedConfig); err != nil {
+	err = stmt.QueryContext(ctx, GetDB().db, &updatedConfig)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.Configuration{}, ErrNotFound
+	} else if err != nil {
 		return appmodel.Configuration{}, fmt.Errorf("upserting config: %v", err)
 	}
 
 	return toAppConfig(updatedConfig)
 }
 
-func GetConfig(ctx context.Context) (appmodel.Configuration, error) {
+func GetConfig(ctx context.Context, configID int64) (appmodel.Configuration, error) {
 	var dbConfig model.Configuration
 	err := Configuration.
 		SELECT(Configuration.AllColumns).
+		WHERE(Configuration.ID.EQ(Int(configID))).
 		QueryContext(ctx, GetDB().db, &dbConfig)
 	if errors.Is(err, qrm.ErrNoRows) {
 		return appmodel.Configuration{}, ErrNotFound
@@ -137,20 +164,96 @@ func GetConfig(ctx context.Context) (appmodel.Configuration, error) {
 	return toAppConfig(dbConfig)
 }
 
-func SetConfigActiveState(ctx context.Context, state bool) error {
+func GetConfigs(ctx context.Context) ([]appmodel.Configuration, error) {
+	var dbConfigs []model.Configuration
+	err := Configuration.
+		SELECT(Configuration.AllColumns).
+		ORDER_BY(Configuration.ID).
+		QueryContext(ctx, GetDB().db, &dbConfigs)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	appConfigs := make([]appmodel.Configuration, 0, len(dbConfigs))
+	for _, dbConfig := range dbConfigs {
+		appConfig, err := toAppConfig(dbConfig)
+		if err != nil {
+			return nil, err
+		}
+		appConfigs = append(appConfigs, appConfig)
+	}
+	return appConfigs, nil
+}
+
+// DeleteConfig deletes a configuration. The app's records of its assets and zone catalogue are
+// deleted with it. The root assets are kept, as other configurations may share their projects.
+func DeleteConfig(ctx context.Context, configID int64) error {
+	result, err := Configuration.DELETE().WHERE(
+		Configuration.ID.EQ(Int(configID)),
+	).ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return fmt.Errorf("deleting config: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func SetConfigActiveState(ctx context.Context, configID int64, state bool) error {
 	stmt := Configuration.UPDATE(Configuration.Active).
 		SET(state).
-		WHERE(Bool(true))
+		WHERE(Configuration.ID.EQ(Int(configID)))
 	_, err := stmt.ExecContext(ctx, GetDB().db)
 	return err
 }
 
+// CountAPIRequest counts a request to the Electricity Maps API unless the configuration already
+// made the limit of requests in the current month. Returns the number of requests made in the
+// month and whether the request was counted.
+func CountAPIRequest(ctx context.Context, configID int64, limit int64) (int64, bool, error) {
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	stmt := APIUsage.INSERT(
+		APIUsage.ConfigurationID,
+		APIUsage.Month,
+		APIUsage.Requests,
+	).VALUES(
+		configID,
+		DateT(month),
+		1,
+	).ON_CONFLICT(
+		APIUsage.ConfigurationID,
+		APIUsage.Month,
+	).DO_UPDATE(
+		SET(
+			APIUsage.Requests.SET(APIUsage.Requests.ADD(Int(1))),
+		).WHERE(
+			APIUsage.Requests.LT(Int(limit)),
+		),
+	).RETURNING(
+		APIUsage.Requests,
+	)
+
+	var usage model.APIUsage
+	err := stmt.QueryContext(ctx, GetDB().db, &usage)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return limit, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("counting API request: %v", err)
+	}
+	return usage.Requests, true, nil
+}
+
 func InsertAsset(ctx context.Context, asset appmodel.Asset) error {
 	stmt := Asset.INSERT(
+		Asset.ConfigurationID,
 		Asset.ProjectID,
 		Asset.AssetID,
 		Asset.LocationID,
 	).VALUES(
+		asset.ConfigurationID,
 		asset.ProjectID,
 		asset.AssetID,
 		asset.LocationID,
@@ -163,10 +266,13 @@ func InsertAsset(ctx context.Context, asset appmodel.Asset) error {
 }
 
 func UpdateAssetLocation(ctx context.Context, asset appmodel.Asset) error {
+	// Data of the new zone has to be written regardless of what was written for the old one.
 	stmt := Asset.UPDATE(
 		Asset.LocationID,
+		Asset.LastDatetime,
 	).SET(
 		asset.LocationID,
+		NULL,
 	).WHERE(
 		Asset.ID.EQ(Int(asset.ID)),
 	)
@@ -174,6 +280,30 @@ func UpdateAssetLocation(ctx context.Context, asset appmodel.Asset) error {
 	return err
 }
 
+func SetAssetLastDatetime(ctx context.Context, assetID int32, datetime time.Time) error {
+	stmt := Asset.UPDATE(
+		Asset.LastDatetime,
+	).SET(
+		TimestampzT(datetime),
+	).WHERE(
+		Asset.AssetID.EQ(Int32(assetID)),
+	)
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
+func SetAssetLastError(ctx context.Context, assetID int32, lastError *string) error {
+	stmt := Asset.UPDATE(
+		Asset.LastError,
+	).SET(
+		lastError,
+	).WHERE(
+		Asset.AssetID.EQ(Int32(assetID)),
+	)
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
 func GetAssetId(ctx context.Context, config appmodel.Configuration, projectID, assetID int32) (*int32, error) {
 	var dest struct {
 		ID int32
@@ -211,12 +341,14 @@ func GetAssetById(assetId int32) (appmodel.Asset, error) {
 	return toAppAsset(asset), nil
 }
 
-func GetAssets(ctx context.Context) ([]appmodel.Asset, error) {
+func GetAssets(ctx context.Context, configID int64) ([]appmodel.Asset, error) {
 	var assets []model.Asset
 	err := SELECT(
 		Asset.AllColumns,
 	).FROM(
 		Asset,
+	).WHERE(
+		Asset.ConfigurationID.EQ(Int(configID)),
 	).QueryContext(ctx, GetDB().db, &assets)
 	if errors.Is(err, qrm.ErrNoRows) {
 		return nil, ErrNotFound
@@ -233,41 +365,74 @@ func GetAssets(ctx context.Context) ([]appmodel.Asset, error) {
 }
 
 func toAppConfig(dbCfg model.Configuration) (appmodel.Configuration, error) {
+	apiKey, err := decryptSecret(dbCfg.APIKey)
+	if err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("decrypting API key of config %d: %v", dbCfg.ID, err)
+	}
 	return appmodel.Configuration{
-		Id:              1,
-		ApiKey:          dbCfg.APIKey,
-		RefreshInterval: dbCfg.RefreshInterval,
-		RequestTimeout:  dbCfg.RequestTimeout,
-		Active:          dbCfg.Active,
-		Enable:          dbCfg.Enable,
-		ProjectIDs:      dbCfg.ProjectIds,
-		UserId:          dbCfg.UserID,
+		Id:                        int64(dbCfg.ID),
+		ApiKey:                    apiKey,
+		RefreshInterval:           dbCfg.RefreshInterval,
+		RequestTimeout:            dbCfg.RequestTimeout,
+		Active:                    dbCfg.Active,
+		Enable:                    dbCfg.Enable,
+		ProjectIDs:                dbCfg.ProjectIds,
+		UserId:                    dbCfg.UserID,
+		BackfillHours:             dbCfg.BackfillHours,
+		ApiBaseUrl:                dbCfg.APIBaseURL,
+		ApiVersion:                dbCfg.APIVersion,
+		RequestsPerSecond:         dbCfg.RequestsPerSecond,
+		MonthlyRequestLimit:       dbCfg.MonthlyRequestLimit,
+		FailureThreshold:          dbCfg.FailureThreshold,
+		Workers:                   dbCfg.Workers,
+		HistoryRetentionDays:      dbCfg.HistoryRetentionDays,
+		AutoProvision:             dbCfg.AutoProvision,
+		BuildingAssetType:         dbCfg.BuildingAssetType,
+		EmissionFactorType:        dbCfg.EmissionFactorType,
+		MarginalSignal:            dbCfg.MarginalSignal,
+		FlexibleLoadHours:         dbCfg.FlexibleLoadHours,
+		FlexibleLoadEarliestStart: dbCfg.FlexibleLoadEarliestStart,
+		FlexibleLoadDeadline:      dbCfg.FlexibleLoadDeadline,
+		TimeZone:                  dbCfg.TimeZone,
 	}, nil
 }
 
 func toAppAsset(dbAsset model.Asset) appmodel.Asset {
-	return appmodel.Asset{
-		ID:         dbAsset.ID,
-		ProjectID:  dbAsset.ProjectID,
-		LocationID: dbAsset.LocationID,
-		AssetID:    dbAsset.AssetID,
+	appAsset := appmodel.Asset{
+		ID:              dbAsset.ID,
+		ConfigurationID: int64(dbAsset.ConfigurationID),
+		ProjectID:       dbAsset.ProjectID,
+		LocationID:      dbAsset.LocationID,
+		AssetID:         dbAsset.AssetID,
+	}
+	if dbAsset.LastDatetime != nil {
+		appAsset.LastDatetime = *dbAsset.LastDatetime
 	}
+	if dbAsset.LastError != nil {
+		appAsset.LastError = *dbAsset.LastError
+	}
+	return appAsset
 }
 
+// UpsertRootAsset stores the root asset of a project. It is shared by all configurations using the
+// project.
 func UpsertRootAsset(assetID int32, projectID, gai string) error {
 	stmt := RootAsset.INSERT(
-		RootAsset.ConfigurationID,
 		RootAsset.Gai,
 		RootAsset.ProjectID,
 		RootAsset.AssetID,
 	).VALUES(
-		1,
 		gai,
 		projectID,
 		assetID,
 	).ON_CONFLICT(
-		RootAsset.AssetID,
-	).DO_NOTHING()
+		RootAsset.ProjectID,
+	).DO_UPDATE(
+		SET(
+			RootAsset.Gai.SET(RootAsset.EXCLUDED.Gai),
+			RootAsset.AssetID.SET(RootAsset.EXCLUDED.AssetID),
+		),
+	)
 
 	if _, err := stmt.ExecContext(context.Background(), GetDB().db); err != nil {
 		return fmt.Errorf("upserting root asset (%v, %v, %v): %v", assetID, projectID, gai, err)
@@ -275,8 +440,25 @@ func UpsertRootAsset(assetID int32, projectID, gai string) error {
 	return nil
 }
 
+func GetProjectRootAsset(ctx context.Context, projectID string) (appmodel.RootAsset, error) {
+	var asset model.RootAsset
+	err := SELECT(
+		RootAsset.AllColumns,
+	).FROM(
+		RootAsset,
+	).WHERE(
+		RootAsset.ProjectID.EQ(String(projectID)),
+	).QueryContext(ctx, GetDB().db, &asset)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.RootAsset{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.RootAsset{}, fmt.Errorf("fetching root asset: %v", err)
+	}
+	return toAppRootAsset(asset), nil
+}
+
 func GetRootAssets() ([]appmodel.RootAsset, error) {
-	var assets []model.Asset
+	var assets []model.RootAsset
 	err := SELECT(
 		RootAsset.AllColumns,
 	).FROM(
@@ -288,14 +470,19 @@ func GetRootAssets() ([]appmodel.RootAsset, error) {
 
 	appAssets := make([]appmodel.RootAsset, 0, len(assets))
 	for _, asset := range assets {
-		appAssets = append(appAssets, appmodel.RootAsset{
-			ID:      asset.ID,
-			AssetID: asset.AssetID,
-		})
+		appAssets = append(appAssets, toAppRootAsset(asset))
 	}
 	return appAssets, nil
 }
 
+func toAppRootAsset(dbAsset model.RootAsset) appmodel.RootAsset {
+	return appmodel.RootAsset{
+		ID:        int64(dbAsset.ID),
+		ProjectID: dbAsset.ProjectID,
+		AssetID:   dbAsset.AssetID,
+	}
+}
+
 func GetRootAssetId(ctx context.Context, projectID, gai string) (*int32, error) {
 	var dest struct {
 		ID int32
@@ -317,19 +504,691 @@ func GetRootAssetId(ctx context.Context, projectID, gai string) (*int32, error)
 	return &dest.ID, nil
 }
 
-func RootAssetAlreadyCreated() (bool, error) {
-	var dest struct {
-		ID int32
+func InsertFlow(ctx context.Context, flow appmodel.Flow, gai string) error {
+	stmt := Flow.INSERT(
+		Flow.ZoneAssetID,
+		Flow.NeighbourZone,
+		Flow.ProjectID,
+		Flow.Gai,
+		Flow.AssetID,
+	).VALUES(
+		flow.ZoneAssetID,
+		flow.NeighbourZone,
+		flow.ProjectID,
+		gai,
+		flow.AssetID,
+	).ON_CONFLICT(
+		Flow.ZoneAssetID,
+		Flow.NeighbourZone,
+	).DO_UPDATE(
+		SET(
+			Flow.AssetID.SET(Flow.EXCLUDED.AssetID),
+		),
+	)
+
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("inserting flow (%v, %v): %v", flow.ZoneAssetID, flow.NeighbourZone, err)
 	}
-	stmt := RootAsset.SELECT(
-		RootAsset.ID,
+	return nil
+}
+
+func GetFlows(ctx context.Context, zoneAssetID int32) ([]appmodel.Flow, error) {
+	var flows []model.Flow
+	err := SELECT(
+		Flow.AllColumns,
+	).FROM(
+		Flow,
+	).WHERE(
+		Flow.ZoneAssetID.EQ(Int32(zoneAssetID)),
+	).QueryContext(ctx, GetDB().db, &flows)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching flows of asset %v: %v", zoneAssetID, err)
+	}
+
+	appFlows := make([]appmodel.Flow, 0, len(flows))
+	for _, flow := range flows {
+		appFlows = append(appFlows, appmodel.Flow{
+			ID:            flow.ID,
+			ZoneAssetID:   flow.ZoneAssetID,
+			NeighbourZone: flow.NeighbourZone,
+			ProjectID:     flow.ProjectID,
+			AssetID:       flow.AssetID,
+		})
+	}
+	return appFlows, nil
+}
+
+func UpsertZoneReadings(ctx context.Context, readings []appmodel.ZoneReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+	stmt := ZoneReading.INSERT(
+		ZoneReading.ConfigurationID,
+		ZoneReading.Zone,
+		ZoneReading.Datetime,
+		ZoneReading.CarbonIntensity,
+		ZoneReading.RenewablePercentage,
+		ZoneReading.FossilFreePercentage,
+		ZoneReading.PowerConsumptionTotal,
+		ZoneReading.PowerProductionTotal,
+		ZoneReading.PowerImportTotal,
+		ZoneReading.PowerExportTotal,
+		ZoneReading.IsEstimated,
+		ZoneReading.Data,
+	)
+	for _, reading := range readings {
+		stmt = stmt.VALUES(
+			reading.ConfigurationID,
+			reading.Zone,
+			TimestampzT(reading.Datetime),
+			reading.CarbonIntensity,
+			reading.RenewablePercentage,
+			reading.FossilFreePercentage,
+			reading.PowerConsumptionTotal,
+			reading.PowerProductionTotal,
+			reading.PowerImportTotal,
+			reading.PowerExportTotal,
+			reading.IsEstimated,
+			reading.Data,
+		)
+	}
+	// Estimated values are replaced by later, measured ones.
+	stmt = stmt.ON_CONFLICT(
+		ZoneReading.ConfigurationID,
+		ZoneReading.Zone,
+		ZoneReading.Datetime,
+	).DO_UPDATE(
+		SET(
+			ZoneReading.CarbonIntensity.SET(ZoneReading.EXCLUDED.CarbonIntensity),
+			ZoneReading.RenewablePercentage.SET(ZoneReading.EXCLUDED.RenewablePercentage),
+			ZoneReading.FossilFreePercentage.SET(ZoneReading.EXCLUDED.FossilFreePercentage),
+			ZoneReading.PowerConsumptionTotal.SET(ZoneReading.EXCLUDED.PowerConsumptionTotal),
+			ZoneReading.PowerProductionTotal.SET(ZoneReading.EXCLUDED.PowerProductionTotal),
+			ZoneReading.PowerImportTotal.SET(ZoneReading.EXCLUDED.PowerImportTotal),
+			ZoneReading.PowerExportTotal.SET(ZoneReading.EXCLUDED.PowerExportTotal),
+			ZoneReading.IsEstimated.SET(ZoneReading.EXCLUDED.IsEstimated),
+			ZoneReading.Data.SET(ZoneReading.EXCLUDED.Data),
+			ZoneReading.FetchedAt.SET(TimestampzT(time.Now())),
+		),
+	)
+
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("upserting zone readings: %v", err)
+	}
+	return nil
+}
+
+// GetZoneReadings returns the readings of a zone the configuration fetched in the time range [from, to)
+func GetZoneReadings(ctx context.Context, configID int64, zone string, from, to time.Time) ([]appmodel.ZoneReading, error) {
+	var readings []model.ZoneReading
+	err := SELECT(
+		ZoneReading.AllColumns,
+	).FROM(
+		ZoneReading,
+	).WHERE(
+		ZoneReading.ConfigurationID.EQ(Int(configID)).
+			AND(ZoneReading.Zone.EQ(String(zone))).
+			AND(ZoneReading.Datetime.GT_EQ(TimestampzT(from))).
+			AND(ZoneReading.Datetime.LT(TimestampzT(to))),
+	).ORDER_BY(
+		ZoneReading.Datetime,
+	).QueryContext(ctx, GetDB().db, &readings)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching zone readings: %v", err)
+	}
+
+	appReadings := make([]appmodel.ZoneReading, 0, len(readings))
+	for _, reading := range readings {
+		appReadings = append(appReadings, appmodel.ZoneReading{
+			ConfigurationID:       int64(reading.ConfigurationID),
+			Zone:                  reading.Zone,
+			Datetime:              reading.Datetime,
+			CarbonIntensity:       reading.CarbonIntensity,
+			RenewablePercentage:   reading.RenewablePercentage,
+			FossilFreePercentage:  reading.FossilFreePercentage,
+			PowerConsumptionTotal: reading.PowerConsumptionTotal,
+			PowerProductionTotal:  reading.PowerProductionTotal,
+			PowerImportTotal:      reading.PowerImportTotal,
+			PowerExportTotal:      reading.PowerExportTotal,
+			IsEstimated:           reading.IsEstimated,
+			Data:                  reading.Data,
+		})
+	}
+	return appReadings, nil
+}
+
+// DeleteZoneReadingsBefore removes the readings of a configuration older than the given time and
+// returns how many were removed.
+func DeleteZoneReadingsBefore(ctx context.Context, configID int64, before time.Time) (int64, error) {
+	stmt := ZoneReading.DELETE().WHERE(
+		ZoneReading.ConfigurationID.EQ(Int(configID)).
+			AND(ZoneReading.Datetime.LT(TimestampzT(before))),
 	)
-	err := stmt.QueryContext(context.Background(), GetDB().db, &dest)
+	result, err := stmt.ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return 0, fmt.Errorf("deleting zone readings before %v: %v", before, err)
+	}
+	return result.RowsAffected()
+}
+
+// ReplaceZones replaces the whole zone catalogue of a configuration.
+func ReplaceZones(ctx context.Context, configID int64, zones []appmodel.Zone) error {
+	tx, err := GetDB().db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := Zone.DELETE().WHERE(Zone.ConfigurationID.EQ(Int(configID))).ExecContext(ctx, tx); err != nil {
+		return fmt.Errorf("deleting zones: %v", err)
+	}
+	if len(zones) > 0 {
+		stmt := Zone.INSERT(
+			Zone.ConfigurationID,
+			Zone.Code,
+			Zone.Name,
+			Zone.Access,
+			Zone.RefreshedAt,
+			Zone.APIKeyHash,
+		)
+		for _, zone := range zones {
+			stmt = stmt.VALUES(
+				configID,
+				zone.Code,
+				zone.Name,
+				pq.StringArray(zone.Access),
+				TimestampzT(zone.RefreshedAt),
+				zone.APIKeyHash,
+			)
+		}
+		if _, err := stmt.ExecContext(ctx, tx); err != nil {
+			return fmt.Errorf("inserting zones: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func GetZones(ctx context.Context, configID int64) ([]appmodel.Zone, error) {
+	var zones []model.Zone
+	err := SELECT(
+		Zone.AllColumns,
+	).FROM(
+		Zone,
+	).WHERE(
+		Zone.ConfigurationID.EQ(Int(configID)),
+	).QueryContext(ctx, GetDB().db, &zones)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching zones: %v", err)
+	}
+
+	appZones := make([]appmodel.Zone, 0, len(zones))
+	for _, zone := range zones {
+		appZones = append(appZones, appmodel.Zone{
+			Code:        zone.Code,
+			Name:        zone.Name,
+			Access:      zone.Access,
+			RefreshedAt: zone.RefreshedAt,
+			APIKeyHash:  zone.APIKeyHash,
+		})
+	}
+	return appZones, nil
+}
+
+func GetAlarmRules(ctx context.Context) ([]appmodel.AlarmRule, error) {
+	return getAlarmRules(ctx, Bool(true))
+}
+
+func GetAssetAlarmRules(ctx context.Context, assetID int32) ([]appmodel.AlarmRule, error) {
+	return getAlarmRules(ctx, AlarmRule.AssetID.EQ(Int32(assetID)))
+}
+
+func getAlarmRules(ctx context.Context, condition BoolExpression) ([]appmodel.AlarmRule, error) {
+	var rules []model.AlarmRule
+	err := SELECT(
+		AlarmRule.AllColumns,
+	).FROM(
+		AlarmRule,
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		AlarmRule.ID,
+	).QueryContext(ctx, GetDB().db, &rules)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching alarm rules: %v", err)
+	}
+
+	appRules := make([]appmodel.AlarmRule, 0, len(rules))
+	for _, rule := range rules {
+		appRules = append(appRules, toAppAlarmRule(rule))
+	}
+	return appRules, nil
+}
+
+func GetAlarmRule(ctx context.Context, ruleID int64) (appmodel.AlarmRule, error) {
+	var rule model.AlarmRule
+	err := SELECT(
+		AlarmRule.AllColumns,
+	).FROM(
+		AlarmRule,
+	).WHERE(
+		AlarmRule.ID.EQ(Int64(ruleID)),
+	).QueryContext(ctx, GetDB().db, &rule)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.AlarmRule{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.AlarmRule{}, fmt.Errorf("fetching alarm rule: %v", err)
+	}
+	return toAppAlarmRule(rule), nil
+}
+
+// UpsertAlarmRule inserts the rule if it has no ID yet and updates it otherwise.
+func UpsertAlarmRule(ctx context.Context, rule appmodel.AlarmRule) (appmodel.AlarmRule, error) {
+	dbRule := toDbAlarmRule(rule)
+	var stmt Statement
+	if rule.ID == 0 {
+		stmt = AlarmRule.INSERT(
+			AlarmRule.MutableColumns,
+		).MODEL(
+			dbRule,
+		).RETURNING(
+			AlarmRule.AllColumns,
+		)
+	} else {
+		stmt = AlarmRule.UPDATE(
+			AlarmRule.MutableColumns,
+		).MODEL(
+			dbRule,
+		).WHERE(
+			AlarmRule.ID.EQ(Int64(rule.ID)),
+		).RETURNING(
+			AlarmRule.AllColumns,
+		)
+	}
+
+	var upserted model.AlarmRule
+	err := stmt.QueryContext(ctx, GetDB().db, &upserted)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.AlarmRule{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.AlarmRule{}, fmt.Errorf("upserting alarm rule: %v", err)
+	}
+	return toAppAlarmRule(upserted), nil
+}
+
+func DeleteAlarmRule(ctx context.Context, ruleID int64) error {
+	result, err := AlarmRule.DELETE().WHERE(
+		AlarmRule.ID.EQ(Int64(ruleID)),
+	).ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return fmt.Errorf("deleting alarm rule: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetAlarmState returns the state of an alarm rule, an inactive state if it was never evaluated.
+func GetAlarmState(ctx context.Context, ruleID int64) (appmodel.AlarmState, error) {
+	var state model.AlarmState
+	err := SELECT(
+		AlarmState.AllColumns,
+	).FROM(
+		AlarmState,
+	).WHERE(
+		AlarmState.RuleID.EQ(Int64(ruleID)),
+	).QueryContext(ctx, GetDB().db, &state)
 	if errors.Is(err, qrm.ErrNoRows) {
-		return false, nil
+		return appmodel.AlarmState{RuleID: ruleID}, nil
 	} else if err != nil {
-		return false, fmt.Errorf("getting root asset: %v", err)
+		return appmodel.AlarmState{}, fmt.Errorf("fetching alarm state: %v", err)
+	}
+
+	appState := appmodel.AlarmState{
+		RuleID:    state.RuleID,
+		Active:    state.Active,
+		ChangedAt: state.ChangedAt,
+	}
+	if state.ViolatingSince != nil {
+		appState.ViolatingSince = *state.ViolatingSince
+	}
+	return appState, nil
+}
+
+// DeleteAlarmState resets the rule to inactive without notifying about it.
+func DeleteAlarmState(ctx context.Context, ruleID int64) error {
+	_, err := AlarmState.DELETE().WHERE(
+		AlarmState.RuleID.EQ(Int64(ruleID)),
+	).ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return fmt.Errorf("deleting alarm state of rule %v: %v", ruleID, err)
+	}
+	return nil
+}
+
+func UpsertAlarmState(ctx context.Context, state appmodel.AlarmState) error {
+	var violatingSince Expression = NULL
+	if !state.ViolatingSince.IsZero() {
+		violatingSince = TimestampzT(state.ViolatingSince)
+	}
+	stmt := AlarmState.INSERT(
+		AlarmState.RuleID,
+		AlarmState.Active,
+		AlarmState.ViolatingSince,
+		AlarmState.ChangedAt,
+	).VALUES(
+		state.RuleID,
+		state.Active,
+		violatingSince,
+		TimestampzT(state.ChangedAt),
+	).ON_CONFLICT(
+		AlarmState.RuleID,
+	).DO_UPDATE(
+		SET(
+			AlarmState.Active.SET(AlarmState.EXCLUDED.Active),
+			AlarmState.ViolatingSince.SET(AlarmState.EXCLUDED.ViolatingSince),
+			AlarmState.ChangedAt.SET(AlarmState.EXCLUDED.ChangedAt),
+		),
+	)
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("upserting alarm state of rule %v: %v", state.RuleID, err)
+	}
+	return nil
+}
+
+func toAppAlarmRule(dbRule model.AlarmRule) appmodel.AlarmRule {
+	rule := appmodel.AlarmRule{
+		ID:                 dbRule.ID,
+		AssetID:            dbRule.AssetID,
+		Attribute:          dbRule.Attribute,
+		Comparison:         dbRule.Comparison,
+		Threshold:          dbRule.Threshold,
+		Hysteresis:         dbRule.Hysteresis,
+		MinDurationMinutes: dbRule.MinDurationMinutes,
+		Enable:             dbRule.Enable,
+	}
+	if dbRule.Message != nil {
+		rule.Message = *dbRule.Message
+	}
+	return rule
+}
+
+func toDbAlarmRule(rule appmodel.AlarmRule) model.AlarmRule {
+	dbRule := model.AlarmRule{
+		ID:                 rule.ID,
+		AssetID:            rule.AssetID,
+		Attribute:          rule.Attribute,
+		Comparison:         rule.Comparison,
+		Threshold:          rule.Threshold,
+		Hysteresis:         rule.Hysteresis,
+		MinDurationMinutes: rule.MinDurationMinutes,
+		Enable:             rule.Enable,
+	}
+	if rule.Message != "" {
+		dbRule.Message = &rule.Message
+	}
+	return dbRule
+}
+
+func GetBuildingMeters(ctx context.Context) ([]appmodel.BuildingMeter, error) {
+	return getBuildingMeters(ctx, Bool(true))
+}
+
+// GetZoneBuildingMeters returns the meters of the buildings getting their carbon intensity from
+// the zone asset.
+func GetZoneBuildingMeters(ctx context.Context, zoneAssetID int32) ([]appmodel.BuildingMeter, error) {
+	return getBuildingMeters(ctx, BuildingMeter.ZoneAssetID.EQ(Int32(zoneAssetID)))
+}
+
+func getBuildingMeters(ctx context.Context, condition BoolExpression) ([]appmodel.BuildingMeter, error) {
+	var meters []model.BuildingMeter
+	err := SELECT(
+		BuildingMeter.AllColumns,
+	).FROM(
+		BuildingMeter,
+	).WHERE(
+		condition,
+	).ORDER_BY(
+		BuildingMeter.ID,
+	).QueryContext(ctx, GetDB().db, &meters)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching building meters: %v", err)
 	}
 
-	return true, nil
+	appMeters := make([]appmodel.BuildingMeter, 0, len(meters))
+	for _, meter := range meters {
+		appMeters = append(appMeters, toAppBuildingMeter(meter))
+	}
+	return appMeters, nil
+}
+
+func GetBuildingMeter(ctx context.Context, meterID int64) (appmodel.BuildingMeter, error) {
+	var meter model.BuildingMeter
+	err := SELECT(
+		BuildingMeter.AllColumns,
+	).FROM(
+		BuildingMeter,
+	).WHERE(
+		BuildingMeter.ID.EQ(Int64(meterID)),
+	).QueryContext(ctx, GetDB().db, &meter)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.BuildingMeter{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.BuildingMeter{}, fmt.Errorf("fetching building meter: %v", err)
+	}
+	return toAppBuildingMeter(meter), nil
+}
+
+// UpsertBuildingMeter inserts the meter if it has no ID yet and updates it otherwise. The
+// emissions are calculated anew for a changed meter.
+func UpsertBuildingMeter(ctx context.Context, meter appmodel.BuildingMeter) (appmodel.BuildingMeter, error) {
+	var meterUnit Expression = NULL
+	if meter.MeterUnit != "" {
+		meterUnit = String(meter.MeterUnit)
+	}
+	var stmt Statement
+	if meter.ID == 0 {
+		stmt = BuildingMeter.INSERT(
+			BuildingMeter.BuildingAssetID,
+			BuildingMeter.ZoneAssetID,
+			BuildingMeter.ProjectID,
+			BuildingMeter.MeterAssetID,
+			BuildingMeter.MeterAttribute,
+			BuildingMeter.MeterUnit,
+		).VALUES(
+			meter.BuildingAssetID,
+			meter.ZoneAssetID,
+			meter.ProjectID,
+			meter.MeterAssetID,
+			meter.MeterAttribute,
+			meterUnit,
+		).RETURNING(
+			BuildingMeter.AllColumns,
+		)
+	} else {
+		stmt = BuildingMeter.UPDATE(
+			BuildingMeter.BuildingAssetID,
+			BuildingMeter.ZoneAssetID,
+			BuildingMeter.ProjectID,
+			BuildingMeter.MeterAssetID,
+			BuildingMeter.MeterAttribute,
+			BuildingMeter.MeterUnit,
+			BuildingMeter.EmissionsUntil,
+		).SET(
+			meter.BuildingAssetID,
+			meter.ZoneAssetID,
+			meter.ProjectID,
+			meter.MeterAssetID,
+			meter.MeterAttribute,
+			meterUnit,
+			NULL,
+		).WHERE(
+			BuildingMeter.ID.EQ(Int64(meter.ID)),
+		).RETURNING(
+			BuildingMeter.AllColumns,
+		)
+	}
+
+	var upserted model.BuildingMeter
+	err := stmt.QueryContext(ctx, GetDB().db, &upserted)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.BuildingMeter{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.BuildingMeter{}, fmt.Errorf("upserting building meter: %v", err)
+	}
+	return toAppBuildingMeter(upserted), nil
+}
+
+func DeleteBuildingMeter(ctx context.Context, meterID int64) error {
+	result, err := BuildingMeter.DELETE().WHERE(
+		BuildingMeter.ID.EQ(Int64(meterID)),
+	).ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return fmt.Errorf("deleting building meter: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetBuildingMeterAsset stores the asset the emissions of the building are written to.
+func SetBuildingMeterAsset(ctx context.Context, meterID int64, assetID int32, gai string) error {
+	stmt := BuildingMeter.UPDATE(
+		BuildingMeter.AssetID,
+		BuildingMeter.Gai,
+	).SET(
+		assetID,
+		gai,
+	).WHERE(
+		BuildingMeter.ID.EQ(Int64(meterID)),
+	)
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("setting emissions asset of building meter %v: %v", meterID, err)
+	}
+	return nil
+}
+
+func SetBuildingMeterEmissionsUntil(ctx context.Context, meterID int64, until time.Time) error {
+	stmt := BuildingMeter.UPDATE(
+		BuildingMeter.EmissionsUntil,
+	).SET(
+		TimestampzT(until),
+	).WHERE(
+		BuildingMeter.ID.EQ(Int64(meterID)),
+	)
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
+func toAppBuildingMeter(dbMeter model.BuildingMeter) appmodel.BuildingMeter {
+	meter := appmodel.BuildingMeter{
+		ID:              dbMeter.ID,
+		BuildingAssetID: dbMeter.BuildingAssetID,
+		ZoneAssetID:     dbMeter.ZoneAssetID,
+		ProjectID:       dbMeter.ProjectID,
+		MeterAssetID:    dbMeter.MeterAssetID,
+		MeterAttribute:  dbMeter.MeterAttribute,
+	}
+	if dbMeter.MeterUnit != nil {
+		meter.MeterUnit = *dbMeter.MeterUnit
+	}
+	if dbMeter.AssetID != nil {
+		meter.AssetID = *dbMeter.AssetID
+	}
+	if dbMeter.EmissionsUntil != nil {
+		meter.EmissionsUntil = *dbMeter.EmissionsUntil
+	}
+	return meter
+}
+
+func GetBuildingZones(ctx context.Context, configID int64) ([]appmodel.BuildingZone, error) {
+	var buildingZones []model.BuildingZone
+	err := SELECT(
+		BuildingZone.AllColumns,
+	).FROM(
+		BuildingZone,
+	).WHERE(
+		BuildingZone.ConfigurationID.EQ(Int(configID)),
+	).QueryContext(ctx, GetDB().db, &buildingZones)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching building zones: %v", err)
+	}
+
+	appBuildingZones := make([]appmodel.BuildingZone, 0, len(buildingZones))
+	for _, buildingZone := range buildingZones {
+		appBuildingZone := appmodel.BuildingZone{
+			BuildingAssetID: buildingZone.BuildingAssetID,
+			Latitude:        buildingZone.Latitude,
+			Longitude:       buildingZone.Longitude,
+		}
+		if buildingZone.Zone != nil {
+			appBuildingZone.Zone = *buildingZone.Zone
+		}
+		if buildingZone.ZoneName != nil {
+			appBuildingZone.ZoneName = *buildingZone.ZoneName
+		}
+		if buildingZone.ZoneAssetID != nil {
+			appBuildingZone.ZoneAssetID = *buildingZone.ZoneAssetID
+		}
+		appBuildingZones = append(appBuildingZones, appBuildingZone)
+	}
+	return appBuildingZones, nil
+}
+
+// UpsertBuildingZone stores where the building was located. The link to a zone asset is reset, as
+// the zone may have changed.
+func UpsertBuildingZone(ctx context.Context, configID int64, buildingZone appmodel.BuildingZone) error {
+	var zone, zoneName Expression = NULL, NULL
+	if buildingZone.Zone != "" {
+		zone, zoneName = String(buildingZone.Zone), String(buildingZone.ZoneName)
+	}
+	stmt := BuildingZone.INSERT(
+		BuildingZone.ConfigurationID,
+		BuildingZone.BuildingAssetID,
+		BuildingZone.Latitude,
+		BuildingZone.Longitude,
+		BuildingZone.Zone,
+		BuildingZone.ZoneName,
+	).VALUES(
+		configID,
+		buildingZone.BuildingAssetID,
+		buildingZone.Latitude,
+		buildingZone.Longitude,
+		zone,
+		zoneName,
+	).ON_CONFLICT(
+		BuildingZone.ConfigurationID,
+		BuildingZone.BuildingAssetID,
+	).DO_UPDATE(
+		SET(
+			BuildingZone.Latitude.SET(BuildingZone.EXCLUDED.Latitude),
+			BuildingZone.Longitude.SET(BuildingZone.EXCLUDED.Longitude),
+			BuildingZone.Zone.SET(BuildingZone.EXCLUDED.Zone),
+			BuildingZone.ZoneName.SET(BuildingZone.EXCLUDED.ZoneName),
+			BuildingZone.ZoneAssetID.SET(IntExp(NULL)),
+			BuildingZone.LocatedAt.SET(TimestampzExp(NOW())),
+		),
+	)
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("upserting zone of building %v: %v", buildingZone.BuildingAssetID, err)
+	}
+	return nil
+}
+
+func SetBuildingZoneAsset(ctx context.Context, configID int64, buildingAssetID int32, zoneAssetID int32) error {
+	stmt := BuildingZone.UPDATE(
+		BuildingZone.ZoneAssetID,
+	).SET(
+		zoneAssetID,
+	).WHERE(
+		BuildingZone.ConfigurationID.EQ(Int(configID)).AND(
+			BuildingZone.BuildingAssetID.EQ(Int32(buildingAssetID)),
+		),
+	)
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		return fmt.Errorf("linking building %v to zone asset %v: %v", buildingAssetID, zoneAssetID, err)
+	}
+	return nil
 }
@@ -0,0 +1,169 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// MigrationsDir holds the migrations of the electricity_maps schema
+const MigrationsDir = "db/migrations"
+
+// migrationFileName matches migration files like 0002_add_asset_settings.sql
+var migrationFileName = regexp.MustCompile(`^(\d{4})_(\w+)\.sql$`)
+
+type migration struct {
+	version int
+	name    string
+	path    string
+}
+
+// Migrate applies the migrations in the directory that are not recorded in the schema_version
+// table yet, ordered by their version. Each migration runs in a transaction of its own and is
+// recorded in the same transaction, so that a failing migration is retried on the next start.
+func Migrate(ctx context.Context, conn db.Connection, schema string, dir string) error {
+	migrations, err := readMigrations(dir)
+	if err != nil {
+		return err
+	}
+	applied, err := appliedVersions(ctx, conn, schema)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := applyMigration(ctx, conn, schema, m); err != nil {
+			return fmt.Errorf("applying migration %04d %s: %v", m.version, m.name, err)
+		}
+		log.Info("dbhelper", "Applied migration %04d %s.", m.version, m.name)
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+
+	// Tables created by the migrations have to be made accessible to the app's database user.
+	if _, err := conn.Exec(ctx, fmt.Sprintf("select fixprivilege('%s','%s')", schema, db.Username())); err != nil {
+		log.Warn("dbhelper", "Cannot fix privileges for schema %s: %v", schema, err)
+	}
+	return nil
+}
+
+func readMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %v", err)
+	}
+
+	var migrations []migration
+	versions := make(map[int]string)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("parsing version of migration %s: %v", entry.Name(), err)
+		}
+		if other, exists := versions[version]; exists {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), version)
+		}
+		versions[version] = entry.Name()
+		migrations = append(migrations, migration{
+			version: version,
+			name:    match[2],
+			path:    filepath.Join(dir, entry.Name()),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// appliedVersions returns the versions recorded in the schema_version table. The table does not
+// exist before the first migration.
+func appliedVersions(ctx context.Context, conn db.Connection, schema string) (map[int]bool, error) {
+	applied := make(map[int]bool)
+
+	var exists bool
+	rows, err := conn.Query(ctx, "select to_regclass($1) is not null", schema+".schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("checking for schema_version table: %v", err)
+	}
+	for rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("checking for schema_version table: %v", err)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("checking for schema_version table: %v", err)
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err = conn.Query(ctx, fmt.Sprintf("select version from %s.schema_version", schema))
+	if err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("reading applied migrations: %v", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func applyMigration(ctx context.Context, conn db.Connection, schema string, m migration) error {
+	sql, err := os.ReadFile(m.path)
+	if err != nil {
+		return fmt.Errorf("reading file: %v", err)
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, string(sql)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf("insert into %s.schema_version (version, name) values ($1, $2)", schema), m.version, m.name); err != nil {
+		return fmt.Errorf("recording migration: %v", err)
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,238 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"golang.org/x/crypto/scrypt"
+
+	"electricity-maps/db/generated/postgres/electricity_maps/model"
+	. "electricity-maps/db/generated/postgres/electricity_maps/table"
+
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// Environment variables holding the passphrases the API keys are encrypted with. The previous one
+// is only used for decrypting, so that the passphrase can be rotated.
+const (
+	encryptionKeyEnv         = "API_KEY_ENCRYPTION_KEY"
+	previousEncryptionKeyEnv = "API_KEY_ENCRYPTION_KEY_PREVIOUS"
+)
+
+// encryptedPrefix marks encrypted API keys together with the version of the format. Version 2
+// derives the key with scrypt and a random salt stored in front of the nonce. Version 1 used the
+// SHA-256 of the passphrase and is only decrypted anymore.
+const (
+	encryptedPrefix   = "enc:v2:"
+	encryptedPrefixV1 = "enc:v1:"
+)
+
+// scrypt parameters recommended for interactive logins. The salt is stored with every value.
+const (
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 16
+	keyLen        = 32
+)
+
+var ErrNoEncryptionKey = errors.New("no encryption key configured")
+
+// derivedKeys caches the keys derived with scrypt by passphrase and salt, as the configurations are
+// decrypted on every read.
+var (
+	derivedKeys     = make(map[string][]byte)
+	derivedKeysLock sync.Mutex
+)
+
+// passphrases returns the current and the previous passphrase, empty if not set.
+func passphrases() []string {
+	return []string{os.Getenv(encryptionKeyEnv), os.Getenv(previousEncryptionKeyEnv)}
+}
+
+// deriveKey derives the AES-256 key from the passphrase and salt with scrypt.
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	cacheKey := passphrase + "\x00" + string(salt)
+	derivedKeysLock.Lock()
+	defer derivedKeysLock.Unlock()
+	if key, ok := derivedKeys[cacheKey]; ok {
+		return key, nil
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %v", err)
+	}
+	derivedKeys[cacheKey] = key
+	return key, nil
+}
+
+// deriveKeyV1 derives the key of the version 1 format.
+func deriveKeyV1(passphrase string) []byte {
+	key := sha256.Sum256([]byte(passphrase))
+	return key[:]
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptSecret encrypts the value with AES-GCM. Without an encryption key, the value is returned
+// as it is and encrypted by EncryptAPIKeys once a key is provided.
+func encryptSecret(plain string) (string, error) {
+	passphrase := os.Getenv(encryptionKeyEnv)
+	if passphrase == "" {
+		return plain, nil
+	}
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("creating salt: %v", err)
+	}
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", fmt.Errorf("creating cipher: %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("creating nonce: %v", err)
+	}
+	sealed := gcm.Seal(append(salt, nonce...), nonce, []byte(plain), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret decrypts a value encrypted with the current or the previous encryption key. Values
+// stored unencrypted are returned as they are.
+func decryptSecret(stored string) (string, error) {
+	plain, _, err := decryptSecretWithKey(stored)
+	return plain, err
+}
+
+// decryptSecretWithKey also tells whether the value is encrypted with the current key and format
+// already.
+func decryptSecretWithKey(stored string) (plain string, current bool, err error) {
+	encoded, v2 := strings.CutPrefix(stored, encryptedPrefix)
+	if !v2 {
+		var v1 bool
+		if encoded, v1 = strings.CutPrefix(stored, encryptedPrefixV1); !v1 {
+			return stored, false, nil
+		}
+	}
+	keys := passphrases()
+	if keys[0] == "" && keys[1] == "" {
+		return "", false, ErrNoEncryptionKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false, fmt.Errorf("decoding encrypted value: %v", err)
+	}
+	var salt []byte
+	if v2 {
+		if len(sealed) < scryptSaltLen {
+			return "", false, errors.New("encrypted value is too short")
+		}
+		salt, sealed = sealed[:scryptSaltLen], sealed[scryptSaltLen:]
+	}
+	for i, passphrase := range keys {
+		if passphrase == "" {
+			continue
+		}
+		key := deriveKeyV1(passphrase)
+		if v2 {
+			if key, err = deriveKey(passphrase, salt); err != nil {
+				return "", false, err
+			}
+		}
+		gcm, err := newGCM(key)
+		if err != nil {
+			return "", false, fmt.Errorf("creating cipher: %v", err)
+		}
+		if len(sealed) < gcm.NonceSize() {
+			return "", false, errors.New("encrypted value is too short")
+		}
+		opened, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+		if err == nil {
+			return string(opened), v2 && i == 0, nil
+		}
+	}
+	return "", false, fmt.Errorf("none of the encryption keys in %s and %s fits", encryptionKeyEnv, previousEncryptionKeyEnv)
+}
+
+// EncryptAPIKeys encrypts the API keys stored unencrypted, in the version 1 format or with the
+// previous encryption key with the current one. Run at startup, it migrates existing configurations
+// and completes a rotation. Without an encryption key, the API keys are kept as they are.
+func EncryptAPIKeys(ctx context.Context) error {
+	if os.Getenv(encryptionKeyEnv) == "" {
+		log.Warn("conf", "%s is not set, API keys are stored unencrypted until it is.", encryptionKeyEnv)
+		return nil
+	}
+
+	var configs []model.Configuration
+	err := SELECT(
+		Configuration.ID,
+		Configuration.APIKey,
+	).FROM(
+		Configuration,
+	).QueryContext(ctx, GetDB().db, &configs)
+	if err != nil {
+		return fmt.Errorf("fetching API keys: %v", err)
+	}
+
+	for _, config := range configs {
+		plain, current, err := decryptSecretWithKey(config.APIKey)
+		if err != nil {
+			return fmt.Errorf("decrypting API key of config %d: %v", config.ID, err)
+		}
+		if current {
+			continue
+		}
+		encrypted, err := encryptSecret(plain)
+		if err != nil {
+			return fmt.Errorf("encrypting API key of config %d: %v", config.ID, err)
+		}
+		_, err = Configuration.UPDATE(
+			Configuration.APIKey,
+		).SET(
+			encrypted,
+		).WHERE(
+			Configuration.ID.EQ(Int32(config.ID)),
+		).ExecContext(ctx, GetDB().db)
+		if err != nil {
+			return fmt.Errorf("storing encrypted API key of config %d: %v", config.ID, err)
+		}
+		log.Info("conf", "Encrypted API key of config %d with the current encryption key.", config.ID)
+	}
+	return nil
+}
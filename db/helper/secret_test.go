@@ -0,0 +1,99 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "current passphrase")
+
+	encrypted, err := encryptSecret("api-key")
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, encryptedPrefix) || strings.Contains(encrypted, "api-key") {
+		t.Errorf("got %q, want an encrypted value", encrypted)
+	}
+	plain, current, err := decryptSecretWithKey(encrypted)
+	if err != nil || plain != "api-key" || !current {
+		t.Errorf("got %q, %t, %v, want %q, true, nil", plain, current, err, "api-key")
+	}
+}
+
+func TestEncryptSecretWithoutKey(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "")
+
+	stored, err := encryptSecret("api-key")
+	if err != nil || stored != "api-key" {
+		t.Errorf("got %q, %v, want %q, nil", stored, err, "api-key")
+	}
+}
+
+func TestDecryptSecretV1(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "current passphrase")
+	t.Setenv(previousEncryptionKeyEnv, "")
+
+	// Sealed with the SHA-256 of the passphrase like version 1 did.
+	gcm, err := newGCM(deriveKeyV1("current passphrase"))
+	if err != nil {
+		t.Fatalf("creating cipher: %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	stored := encryptedPrefixV1 + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte("api-key"), nil))
+
+	plain, current, err := decryptSecretWithKey(stored)
+	if err != nil || plain != "api-key" || current {
+		t.Errorf("got %q, %t, %v, want %q, false, nil", plain, current, err, "api-key")
+	}
+}
+
+func TestDecryptSecretRotation(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "old passphrase")
+	encrypted, err := encryptSecret("api-key")
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+
+	t.Setenv(encryptionKeyEnv, "new passphrase")
+	t.Setenv(previousEncryptionKeyEnv, "old passphrase")
+	plain, current, err := decryptSecretWithKey(encrypted)
+	if err != nil || plain != "api-key" || current {
+		t.Errorf("got %q, %t, %v, want %q, false, nil", plain, current, err, "api-key")
+	}
+
+	t.Setenv(previousEncryptionKeyEnv, "")
+	if _, err := decryptSecret(encrypted); err == nil {
+		t.Error("decrypting with the wrong key succeeded")
+	}
+}
+
+func TestDecryptSecretLegacy(t *testing.T) {
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(previousEncryptionKeyEnv, "")
+
+	plain, current, err := decryptSecretWithKey("api-key")
+	if err != nil || plain != "api-key" || current {
+		t.Errorf("got %q, %t, %v, want %q, false, nil", plain, current, err, "api-key")
+	}
+	if _, err := decryptSecret(encryptedPrefix + "AAAA"); !errors.Is(err, ErrNoEncryptionKey) {
+		t.Errorf("got %v, want %v", err, ErrNoEncryptionKey)
+	}
+}
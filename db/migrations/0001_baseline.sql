@@ -13,8 +13,20 @@
 --  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
 --  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
 
+-- Schema of the first release, which was created by db/init.sql before versioned migrations were
+-- introduced. The statements are idempotent, so that installations of the first release are taken
+-- over as well as new ones created.
+
 create schema if not exists electricity_maps;
 
+-- Migrations applied to the schema, see dbhelper.Migrate.
+create table if not exists electricity_maps.schema_version
+(
+	version          integer     primary key,
+	name             text        not null,
+	applied_at       timestamptz not null default now()
+);
+
 -- Should be editable by eliona frontend.
 create table if not exists electricity_maps.configuration
 (
@@ -44,8 +56,3 @@ create table if not exists electricity_maps.root_asset
 	gai              text      not null,
 	asset_id         integer   not null unique
 );
-
--- There is a transaction started in app.Init(). We need to commit to make the
--- new objects available for all other init steps.
--- Chain starts the same transaction again.
-commit and chain;
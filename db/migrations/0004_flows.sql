@@ -0,0 +1,26 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Child assets of a zone asset holding the cross-border flows to one neighbouring zone.
+create table electricity_maps.flow
+(
+	id               bigserial primary key,
+	zone_asset_id    integer   not null references electricity_maps.asset(asset_id) ON DELETE CASCADE,
+	neighbour_zone   text      not null,
+	project_id       text      not null,
+	gai              text      not null,
+	asset_id         integer   not null unique,
+	unique (zone_asset_id, neighbour_zone)
+);
@@ -0,0 +1,26 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+alter table electricity_maps.configuration add column requests_per_second double precision not null default 5;
+alter table electricity_maps.configuration add column monthly_request_limit bigint not null default 0;
+
+-- Requests made to the Electricity Maps API per month, to keep within the budget of the API plan.
+create table electricity_maps.api_usage
+(
+	configuration_id int    not null references electricity_maps.configuration(id) ON DELETE CASCADE,
+	month            date   not null,
+	requests         bigint not null default 0,
+	primary key (configuration_id, month)
+);
@@ -0,0 +1,35 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+alter table electricity_maps.configuration add column history_retention_days integer not null default 30;
+
+-- Every zone reading fetched from Electricity Maps. The full response is kept in data, the
+-- headline values are normalised into columns for querying.
+create table electricity_maps.zone_reading
+(
+	zone                    text             not null,
+	datetime                timestamptz      not null,
+	carbon_intensity        double precision not null,
+	renewable_percentage    double precision not null,
+	fossil_free_percentage  double precision not null,
+	power_consumption_total double precision not null,
+	power_production_total  double precision not null,
+	power_import_total      double precision not null,
+	power_export_total      double precision not null,
+	is_estimated            boolean          not null default false,
+	data                    jsonb            not null,
+	fetched_at              timestamptz      not null default now(),
+	primary key (zone, datetime)
+);
@@ -0,0 +1,31 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Energy meters of buildings, whose consumption the Scope 2 emissions are calculated for. The
+-- carbon intensity is taken from the zone of the building's zone asset, which buildings located in
+-- the same zone share. The emissions are written to an asset created below the building.
+create table electricity_maps.building_meter
+(
+	id                bigserial   primary key,
+	building_asset_id integer     not null unique,
+	zone_asset_id     integer     not null references electricity_maps.asset(asset_id) ON DELETE CASCADE,
+	project_id        text        not null,
+	meter_asset_id    integer     not null,
+	meter_attribute   text        not null,
+	meter_unit        text,
+	gai               text,
+	asset_id          integer     unique,
+	emissions_until   timestamptz
+);
@@ -0,0 +1,37 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Alarm rules on the attributes of zone assets. A rule fires once the value has been beyond the
+-- threshold for the minimum duration and clears once it is back by more than the hysteresis.
+create table electricity_maps.alarm_rule
+(
+	id                   bigserial        primary key,
+	asset_id             integer          not null references electricity_maps.asset(asset_id) ON DELETE CASCADE,
+	attribute            text             not null,
+	comparison           text             not null check (comparison in ('above', 'below')),
+	threshold            double precision not null,
+	hysteresis           double precision not null default 0,
+	min_duration_minutes integer          not null default 0,
+	enable               boolean          not null default true,
+	message              text
+);
+
+create table electricity_maps.alarm_state
+(
+	rule_id          bigint      primary key references electricity_maps.alarm_rule(id) ON DELETE CASCADE,
+	active           boolean     not null default false,
+	violating_since  timestamptz,
+	changed_at       timestamptz not null default now()
+);
@@ -0,0 +1,45 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- The configuration used to be limited to a single one with id 1.
+alter table electricity_maps.configuration drop constraint configuration_id_check;
+create sequence electricity_maps.configuration_id_seq owned by electricity_maps.configuration.id;
+alter table electricity_maps.configuration alter column id set default nextval('electricity_maps.configuration_id_seq');
+select setval('electricity_maps.configuration_id_seq', coalesce(max(id), 0) + 1, false) from electricity_maps.configuration;
+
+-- Existing assets belong to the single configuration. Without one, they could not be collected and
+-- are dropped. The Eliona assets stay and are taken over again once their properties are saved.
+alter table electricity_maps.asset add column configuration_id int references electricity_maps.configuration(id) ON DELETE CASCADE;
+update electricity_maps.asset set configuration_id = (select min(id) from electricity_maps.configuration);
+delete from electricity_maps.asset where configuration_id is null;
+alter table electricity_maps.asset alter column configuration_id set not null;
+
+-- Each configuration has a root asset per project.
+alter table electricity_maps.root_asset drop constraint root_asset_configuration_id_key;
+alter table electricity_maps.root_asset drop constraint root_asset_asset_id_key;
+alter table electricity_maps.root_asset add constraint root_asset_configuration_id_project_id_key unique (configuration_id, project_id);
+
+-- Each API key has a catalogue of its own. The shared one is a mere cache, so it is dropped and
+-- fetched again.
+drop table electricity_maps.zone;
+create table electricity_maps.zone
+(
+	configuration_id int         not null references electricity_maps.configuration(id) ON DELETE CASCADE,
+	code             text        not null,
+	name             text        not null,
+	access           text[]      not null,
+	refreshed_at     timestamptz not null default now(),
+	primary key (configuration_id, code)
+);
@@ -0,0 +1,30 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Zones the buildings of auto-provisioned projects are located in, so that a building costs an API
+-- request only once, and the zone asset each building is linked to. Zone is null if there is no
+-- zone at the building's coordinates. A building is located again once its coordinates change.
+create table electricity_maps.building_zone
+(
+	configuration_id  int              not null references electricity_maps.configuration(id) ON DELETE CASCADE,
+	building_asset_id integer          not null,
+	latitude          double precision not null,
+	longitude         double precision not null,
+	zone              text,
+	zone_name         text,
+	zone_asset_id     integer          references electricity_maps.asset(asset_id) ON DELETE SET NULL,
+	located_at        timestamptz      not null default now(),
+	primary key (configuration_id, building_asset_id)
+);
@@ -0,0 +1,24 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Each configuration keeps the readings it fetched, as configurations with other emission factor
+-- types get other carbon intensities for the same zone. Existing readings belong to the
+-- configuration that used to be the only one.
+alter table electricity_maps.zone_reading add column configuration_id int references electricity_maps.configuration(id) ON DELETE CASCADE;
+update electricity_maps.zone_reading set configuration_id = (select min(id) from electricity_maps.configuration);
+delete from electricity_maps.zone_reading where configuration_id is null;
+alter table electricity_maps.zone_reading alter column configuration_id set not null;
+alter table electricity_maps.zone_reading drop constraint zone_reading_pkey;
+alter table electricity_maps.zone_reading add primary key (configuration_id, zone, datetime);
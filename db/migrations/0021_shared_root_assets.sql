@@ -0,0 +1,23 @@
+--  This file is part of the Eliona project.
+--  Copyright © 2025 IoTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- The root asset of a project is shared by all configurations using the project, as its GAI is the
+-- same for all of them. Of the root assets of a project, the oldest one is kept.
+delete from electricity_maps.root_asset r using electricity_maps.root_asset o
+where r.project_id = o.project_id and r.id > o.id;
+alter table electricity_maps.root_asset drop constraint root_asset_configuration_id_project_id_key;
+alter table electricity_maps.root_asset drop column configuration_id;
+alter table electricity_maps.root_asset add constraint root_asset_project_id_key unique (project_id);
+alter table electricity_maps.root_asset add constraint root_asset_asset_id_key unique (asset_id);
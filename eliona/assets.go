@@ -17,7 +17,9 @@ package eliona
 
 import (
 	appmodel "electricity-maps/app/model"
+	"errors"
 	"fmt"
+	"sync"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
@@ -25,50 +27,151 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
-var devicesCount map[int64]int
+var ErrNotFound = errors.New("not found")
+
+var (
+	// devicesCount is shared by the collection workers creating flow assets concurrently.
+	devicesCount      = make(map[int64]int)
+	devicesCountMutex sync.Mutex
+)
 
 func CreateAssets(config appmodel.Configuration, assets []asset.AssetWithParentReferences) error {
-	// TODO: remove this workaround once the assetsCreated is returned correctly againTODO
-	if devicesCount == nil {
-		devicesCount = make(map[int64]int)
-	}
 	for _, projectId := range config.ProjectIDs {
-		// TODO: this does not return assets created anymore, but total number of assets!
-		assetsCreated, err := asset.CreateAssetsBulk(assets, projectId)
-		if err != nil {
+		if err := CreateProjectAssets(config, projectId, assets); err != nil {
 			return err
 		}
-		if assetsCreated != 0 && devicesCount[config.Id] != assetsCreated {
-			if err := notifyUser(config.UserId, projectId, assetsCreated); err != nil {
-				return fmt.Errorf("notifying user about CAC: %v", err)
-			}
-			devicesCount[config.Id] = assetsCreated
+	}
+	return nil
+}
+
+// CreateProjectAssets creates the assets in a single project only.
+func CreateProjectAssets(config appmodel.Configuration, projectId string, assets []asset.AssetWithParentReferences) error {
+	// TODO: this does not return assets created anymore, but total number of assets!
+	assetsCreated, err := asset.CreateAssetsBulk(assets, projectId)
+	if err != nil {
+		return err
+	}
+
+	devicesCountMutex.Lock()
+	notify := assetsCreated != 0 && devicesCount[config.Id] != assetsCreated
+	if notify {
+		devicesCount[config.Id] = assetsCreated
+	}
+	devicesCountMutex.Unlock()
+
+	if notify {
+		if err := notifyUser(config.UserId, projectId, assetsCreated); err != nil {
+			return fmt.Errorf("notifying user about CAC: %v", err)
 		}
 	}
 	return nil
 }
 
 func notifyUser(userId string, projectId string, assetsCreated int) error {
+	err := postNotification(userId, projectId, api.Translation{
+		De: api.PtrString(fmt.Sprintf("Electricity Maps App hat %d neue Assets angelegt. Diese sind nun im Asset-Management verfügbar.", assetsCreated)),
+		En: api.PtrString(fmt.Sprintf("Electricity Maps app added %v new assets. They are now available in Asset Management.", assetsCreated)),
+	})
+	if err != nil {
+		return fmt.Errorf("posting CAC notification: %v", err)
+	}
+	return nil
+}
+
+// NotifyAlarm tells the user that an alarm rule fired or cleared.
+func NotifyAlarm(userId string, projectId string, message api.Translation) error {
+	if err := postNotification(userId, projectId, message); err != nil {
+		return fmt.Errorf("posting alarm notification: %v", err)
+	}
+	return nil
+}
+
+func postNotification(userId string, projectId string, message api.Translation) error {
 	receipt, _, err := client.NewClient().CommunicationAPI.
 		PostNotification(client.AuthenticationContext()).
 		Notification(
 			api.Notification{
 				User:      userId,
 				ProjectId: *api.NewNullableString(&projectId),
-				Message: *api.NewNullableTranslation(&api.Translation{
-					De: api.PtrString(fmt.Sprintf("Electricity Maps App hat %d neue Assets angelegt. Diese sind nun im Asset-Management verfügbar.", assetsCreated)),
-					En: api.PtrString(fmt.Sprintf("Electricity Maps app added %v new assets. They are now available in Asset Management.", assetsCreated)),
-				}),
+				Message:   *api.NewNullableTranslation(&message),
 			}).
 		Execute()
-	log.Debug("eliona", "posted notification about CAC: %v", receipt)
-	if err != nil {
-		return fmt.Errorf("posting CAC notification: %v", err)
-	}
-	return nil
+	log.Debug("eliona", "posted notification: %v", receipt)
+	return err
 }
 
 func GetAsset(assetID int32) (*api.Asset, error) {
 	asset, _, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), assetID).Execute()
 	return asset, err
 }
+
+// GetProjectAssets returns the assets of a type in a project
+func GetProjectAssets(projectID string, assetType string) ([]api.Asset, error) {
+	assets, _, err := client.NewClient().AssetsAPI.GetAssets(client.AuthenticationContext()).
+		ProjectId(projectID).
+		AssetTypeName(assetType).
+		Execute()
+	return assets, err
+}
+
+// GetAttributeUnit returns the unit of an attribute as defined by the asset type of the asset.
+// Returns ErrNotFound if the asset type has no such attribute.
+func GetAttributeUnit(assetID int32, attribute string) (string, error) {
+	typeAttribute, err := getTypeAttribute(assetID, attribute)
+	if err != nil {
+		return "", err
+	}
+	return typeAttribute.GetUnit(), nil
+}
+
+// IsNumericInput tells whether the attribute is an input attribute of the asset's type holding
+// numbers, i.e. having a unit or digital values. Returns ErrNotFound if there is no such attribute.
+func IsNumericInput(assetID int32, attribute string) (bool, error) {
+	typeAttribute, err := getTypeAttribute(assetID, attribute)
+	if err != nil {
+		return false, err
+	}
+	numeric := typeAttribute.GetUnit() != "" || typeAttribute.GetIsDigital()
+	return typeAttribute.GetSubtype() == api.SUBTYPE_INPUT && numeric, nil
+}
+
+func getTypeAttribute(assetID int32, attribute string) (api.AssetTypeAttribute, error) {
+	elionaAsset, err := GetAsset(assetID)
+	if err != nil {
+		return api.AssetTypeAttribute{}, fmt.Errorf("getting asset %v: %v", assetID, err)
+	}
+	assetType, _, err := client.NewClient().AssetTypesAPI.
+		GetAssetTypeByName(client.AuthenticationContext(), elionaAsset.AssetType).
+		Expansions([]string{"AssetType.attributes"}).
+		Execute()
+	if err != nil {
+		return api.AssetTypeAttribute{}, fmt.Errorf("getting asset type %s: %v", elionaAsset.AssetType, err)
+	}
+	for _, typeAttribute := range assetType.GetAttributes() {
+		if typeAttribute.Name == attribute {
+			return typeAttribute, nil
+		}
+	}
+	return api.AssetTypeAttribute{}, fmt.Errorf("%w: attribute %s of asset type %s", ErrNotFound, attribute, elionaAsset.AssetType)
+}
+
+// maxParentDepth limits how far GetCoordinates walks up the locational hierarchy
+const maxParentDepth = 10
+
+// GetCoordinates returns the coordinates of the asset. If the asset has none, the coordinates of
+// the closest locational parent having some are returned.
+func GetCoordinates(asset *api.Asset) (lat, lon float64, ok bool, err error) {
+	for depth := 0; asset != nil && depth <= maxParentDepth; depth++ {
+		if asset.Latitude.Get() != nil && asset.Longitude.Get() != nil {
+			return asset.GetLatitude(), asset.GetLongitude(), true, nil
+		}
+		parentID := asset.ParentLocationalAssetId.Get()
+		if parentID == nil {
+			return 0, 0, false, nil
+		}
+		if asset, err = GetAsset(*parentID); err != nil {
+			return 0, 0, false, fmt.Errorf("getting locational parent %v: %v", *parentID, err)
+		}
+	}
+	return 0, 0, false, nil
+}
@@ -0,0 +1,135 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"electricity-maps/broker"
+	dbhelper "electricity-maps/db/helper"
+	"errors"
+	"fmt"
+	"sort"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// ElectricityMapsDashboard builds the "Electricity Maps" dashboard of a project: the carbon
+// intensity and generation mix of every zone asset followed by the status of the app.
+func ElectricityMapsDashboard(projectID string) (api.Dashboard, error) {
+	dashboard := api.Dashboard{}
+	dashboard.Name = "Electricity Maps"
+	dashboard.ProjectId = projectID
+	dashboard.Widgets = []api.Widget{}
+
+	zones, err := GetProjectAssets(projectID, (&Zone{}).GetAssetType())
+	if err != nil {
+		return api.Dashboard{}, fmt.Errorf("getting zone assets: %v", err)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].GetName() < zones[j].GetName()
+	})
+
+	for _, zone := range zones {
+		dashboard.Widgets = append(dashboard.Widgets,
+			carbonIntensityWidget(zone.GetId(), zone.GetName()),
+			generationMixWidget(zone.GetId(), zone.GetName()),
+		)
+	}
+
+	root, err := dbhelper.GetProjectRootAsset(context.Background(), projectID)
+	if err != nil && !errors.Is(err, dbhelper.ErrNotFound) {
+		return api.Dashboard{}, fmt.Errorf("getting root asset: %v", err)
+	}
+	if err == nil {
+		dashboard.Widgets = append(dashboard.Widgets, appStatusWidget(root.AssetID))
+	}
+
+	for i := range dashboard.Widgets {
+		dashboard.Widgets[i].Sequence = *api.NewNullableInt32(api.PtrInt32(int32(i)))
+	}
+	return dashboard, nil
+}
+
+func carbonIntensityWidget(assetID int32, name string) api.Widget {
+	return api.Widget{
+		WidgetTypeName: "Electricity Maps Carbon Intensity",
+		AssetId:        *api.NewNullableInt32(&assetID),
+		Details: map[string]any{
+			"size":     1,
+			"timespan": 7,
+			"title":    name,
+		},
+		Data: []api.WidgetData{
+			widgetData(0, assetID, "carbon_intensity", api.SUBTYPE_INPUT, "Carbon Intensity", 0),
+			widgetData(1, assetID, "renewable_percentage", api.SUBTYPE_INPUT, "Renewable Share", 0),
+			widgetData(2, assetID, "fossil_free_percentage", api.SUBTYPE_INPUT, "Fossil-Free Share", 0),
+			widgetData(3, assetID, "carbon_intensity", api.SUBTYPE_INPUT, "Carbon Intensity", 0),
+		},
+	}
+}
+
+func generationMixWidget(assetID int32, name string) api.Widget {
+	var sources []string
+	for source := range (broker.PowerBreakdown{}).Sources() {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var data []api.WidgetData
+	for i, source := range sources {
+		data = append(data, widgetData(0, assetID, "production_"+source, api.SUBTYPE_INPUT, source, int32(i)))
+	}
+	return api.Widget{
+		WidgetTypeName: "Electricity Maps Generation Mix",
+		AssetId:        *api.NewNullableInt32(&assetID),
+		Details: map[string]any{
+			"size":     1,
+			"timespan": 7,
+			"title":    name,
+		},
+		Data: data,
+	}
+}
+
+func appStatusWidget(assetID int32) api.Widget {
+	return api.Widget{
+		WidgetTypeName: "Electricity Maps App Status",
+		AssetId:        *api.NewNullableInt32(&assetID),
+		Details: map[string]any{
+			"size":     1,
+			"timespan": 7,
+		},
+		Data: []api.WidgetData{
+			widgetData(0, assetID, "status", api.SUBTYPE_STATUS, "Status", 0),
+			widgetData(1, assetID, "status", api.SUBTYPE_STATUS, "Status", 0),
+		},
+	}
+}
+
+func widgetData(element int32, assetID int32, attribute string, subtype api.DataSubtype, description string, seq int32) api.WidgetData {
+	return api.WidgetData{
+		ElementSequence: *api.NewNullableInt32(&element),
+		AssetId:         *api.NewNullableInt32(&assetID),
+		Data: map[string]any{
+			"aggregatedDataType": "heap",
+			"attribute":          attribute,
+			"description":        description,
+			"key":                "",
+			"seq":                seq,
+			"subtype":            subtype,
+		},
+	}
+}
@@ -17,10 +17,12 @@ package eliona
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 )
 
 const ClientReference string = "electricity-maps"
@@ -41,3 +43,35 @@ func UpsertData(assetID int32, assetData map[string]any, timestamp time.Time, su
 	}
 	return nil
 }
+
+// Sample is a value of an attribute at a point in time.
+type Sample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// GetTrend returns the numeric values an attribute of an asset had in the time range, oldest first.
+func GetTrend(assetID int32, attribute string, subtype api.DataSubtype, from, to time.Time) ([]Sample, error) {
+	trend, _, err := client.NewClient().DataAPI.GetDataTrendById(client.AuthenticationContext(), assetID).
+		AttributeName(attribute).
+		DataSubtype(string(subtype)).
+		FromDate(from.Format(time.RFC3339)).
+		ToDate(to.Format(time.RFC3339)).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("getting trend of %s of asset %v: %v", attribute, assetID, err)
+	}
+
+	samples := make([]Sample, 0, len(trend))
+	for _, data := range trend {
+		value, ok := data.Data[attribute].(float64)
+		if !ok || data.Timestamp.Get() == nil {
+			continue
+		}
+		samples = append(samples, Sample{Timestamp: *data.Timestamp.Get(), Value: value})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, nil
+}
@@ -19,6 +19,10 @@ import (
 	"context"
 	appmodel "electricity-maps/app/model"
 	dbhelper "electricity-maps/db/helper"
+	"fmt"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 )
 
 type Root struct {
@@ -29,15 +33,15 @@ type Root struct {
 }
 
 func (r *Root) GetName() string {
-	return "weather_app"
+	return "Electricity Maps"
 }
 
 func (r *Root) GetDescription() string {
-	return "Root asset for Weather App"
+	return "Root asset for Electricity Maps App"
 }
 
 func (r *Root) GetAssetType() string {
-	return "weather_app_root"
+	return "electricity_maps_root"
 }
 
 func (r *Root) GetGAI() string {
@@ -59,3 +63,124 @@ func (r *Root) GetLocationalParentGAI() string {
 func (r *Root) GetFunctionalParentGAI() string {
 	return r.FunctionalParentGAI
 }
+
+// Flow is a child asset of a zone asset holding the cross-border flows to a neighbouring zone.
+type Flow struct {
+	Zone          string
+	NeighbourZone string
+	ZoneAssetID   int32
+	ZoneGAI       string
+}
+
+func (f *Flow) GetName() string {
+	return fmt.Sprintf("%s ⇄ %s", f.Zone, f.NeighbourZone)
+}
+
+func (f *Flow) GetDescription() string {
+	return fmt.Sprintf("Cross-border electricity flows between %s and %s", f.Zone, f.NeighbourZone)
+}
+
+func (f *Flow) GetAssetType() string {
+	return "electricity_maps_app_flow"
+}
+
+func (f *Flow) GetGAI() string {
+	return fmt.Sprintf("%s_flow_%s", f.ZoneGAI, f.NeighbourZone)
+}
+
+func (f *Flow) SetAssetID(assetID int32, projectID string) error {
+	return dbhelper.InsertFlow(context.Background(), appmodel.Flow{
+		ZoneAssetID:   f.ZoneAssetID,
+		NeighbourZone: f.NeighbourZone,
+		ProjectID:     projectID,
+		AssetID:       assetID,
+	}, f.GetGAI())
+}
+
+func (f *Flow) GetLocationalParentGAI() string {
+	return f.ZoneGAI
+}
+
+func (f *Flow) GetFunctionalParentGAI() string {
+	return f.ZoneGAI
+}
+
+// Zone is an Electricity Zone asset provisioned below a building located in the zone.
+type Zone struct {
+	Code        string
+	Name        string
+	BuildingGAI string
+	ConfigID    int64
+}
+
+func (z *Zone) GetName() string {
+	return fmt.Sprintf("%s - %s", z.Code, z.Name)
+}
+
+func (z *Zone) GetDescription() string {
+	return fmt.Sprintf("Electricity grid data of zone %s", z.Name)
+}
+
+func (z *Zone) GetAssetType() string {
+	return "electricity_maps_app_location"
+}
+
+func (z *Zone) GetGAI() string {
+	return fmt.Sprintf("%s_%s", z.GetAssetType(), z.Code)
+}
+
+func (z *Zone) SetAssetID(assetID int32, projectID string) error {
+	if err := dbhelper.InsertAsset(context.Background(), appmodel.Asset{
+		ConfigurationID: z.ConfigID,
+		ProjectID:       projectID,
+		AssetID:         assetID,
+		LocationID:      z.Code,
+	}); err != nil {
+		return fmt.Errorf("inserting asset: %v", err)
+	}
+	// The location is filled in as if the user entered it, so the asset can be edited the usual way.
+	return UpsertData(assetID, map[string]any{"name": z.GetName()}, time.Now(), api.SUBTYPE_PROPERTY)
+}
+
+func (z *Zone) GetLocationalParentGAI() string {
+	return z.BuildingGAI
+}
+
+func (z *Zone) GetFunctionalParentGAI() string {
+	return (&Root{}).GetGAI()
+}
+
+// BuildingEmissions is a child asset of a building holding the Scope 2 emissions of its meter.
+type BuildingEmissions struct {
+	MeterID      int64
+	BuildingName string
+	BuildingGAI  string
+}
+
+func (b *BuildingEmissions) GetName() string {
+	return fmt.Sprintf("%s Scope 2 Emissions", b.BuildingName)
+}
+
+func (b *BuildingEmissions) GetDescription() string {
+	return fmt.Sprintf("Location-based Scope 2 emissions of %s", b.BuildingName)
+}
+
+func (b *BuildingEmissions) GetAssetType() string {
+	return "electricity_maps_app_building_emissions"
+}
+
+func (b *BuildingEmissions) GetGAI() string {
+	return fmt.Sprintf("%s_emissions", b.BuildingGAI)
+}
+
+func (b *BuildingEmissions) SetAssetID(assetID int32, projectID string) error {
+	return dbhelper.SetBuildingMeterAsset(context.Background(), b.MeterID, assetID, b.GetGAI())
+}
+
+func (b *BuildingEmissions) GetLocationalParentGAI() string {
+	return b.BuildingGAI
+}
+
+func (b *BuildingEmissions) GetFunctionalParentGAI() string {
+	return (&Root{}).GetGAI()
+}
@@ -3,17 +3,10 @@ setlocal enabledelayedexpansion
 
 go install github.com/eliona-smart-building-assistant/dev-utilities/cmd/db-generator@latest
 
-:: Read the content of init.sql
-set "INIT_SQL_CONTENT="
-for /f "delims=" %%i in ('type "%CD%\db\init.sql"') do (
-    set "line=%%i"
-    set "INIT_SQL_CONTENT=!INIT_SQL_CONTENT!!line!!\n!"
-)
-
-:: Create init_wrapper.sql to run the script in a transaction
+:: Create init_wrapper.sql running all migrations in order in a transaction
 (
     echo BEGIN;
-    echo !INIT_SQL_CONTENT!
+    for %%f in ("%CD%\db\migrations\*.sql") do type "%%f"
     echo COMMIT;
 ) > .\db\init_wrapper.sql
 
@@ -1,17 +1,16 @@
 go install github.com/eliona-smart-building-assistant/dev-utilities/cmd/db-generator@latest
 
-# Read the content of init.sql
-INIT_SQL_CONTENT=$(<"${PWD}/db/init.sql")
-
-# Create init_wrapper.sql to run the script in a transaction. This is needed for
-# COMMIT AND CHAIN to work in the script.
-cat << EOF > ./db/init_wrapper.sql
-BEGIN;
-
-$INIT_SQL_CONTENT
-
-COMMIT;
-EOF
+# Create init_wrapper.sql running all migrations in order in a transaction, like the app
+# does on startup.
+{
+    echo "BEGIN;"
+    for migration in "${PWD}"/db/migrations/*.sql; do
+        echo
+        cat "$migration"
+    done
+    echo
+    echo "COMMIT;"
+} > ./db/init_wrapper.sql
 
 docker run -d \
     --name "app_jet_code_generation" \
@@ -18,5 +18,5 @@ func TestApp(t *testing.T) {
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "electricity_maps", []string{"configuration", "asset"})
+	assert.SchemaExists(t, "electricity_maps", []string{"configuration", "asset", "root_asset", "flow", "api_usage", "zone_reading", "zone", "alarm_rule", "alarm_state", "building_meter", "building_zone", "schema_version"})
 }